@@ -1,19 +1,32 @@
 package jumpserver
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
 
 // Account represents a JumpServer account
 type Account struct {
 	ID           string      `json:"id"`
 	Name         string      `json:"username"`
+	AccountName  string      `json:"name,omitempty"`
 	Asset        interface{} `json:"asset"` // Can be string ID or object with id
 	AssetDisplay string      `json:"asset_display,omitempty"`
 	SecretType   interface{} `json:"secret_type"` // Can be string or object {"value":"", "label":""}
+	Privileged   bool        `json:"privileged"`
+	IsActive     bool        `json:"is_active"`
 	Comment      string      `json:"comment,omitempty"`
 	Created      string      `json:"date_created,omitempty"`
 	Updated      string      `json:"date_updated,omitempty"`
 }
 
+// AccountSecret represents an account together with its secret
+type AccountSecret struct {
+	Account
+	Secret string `json:"secret"`
+}
+
 // GetSecretTypeValue returns the secret_type value as string
 func (a *Account) GetSecretTypeValue() string {
 	switch v := a.SecretType.(type) {
@@ -42,19 +55,35 @@ func (a *Account) GetAssetID() string {
 
 // CreateAccountRequest defines the request to create an account
 type CreateAccountRequest struct {
-	Name       string `json:"username"`
-	Asset      string `json:"asset"`
-	Secret     string `json:"secret"`
-	SecretType string `json:"secret_type"`
-	Comment    string `json:"comment,omitempty"`
+	Name           string `json:"username,omitempty"` // Taken from the template when empty
+	AccountName    string `json:"name,omitempty"`
+	Asset          string `json:"asset"`
+	Secret         string `json:"secret,omitempty"`
+	SecretType     string `json:"secret_type"`
+	SecretStrategy string `json:"secret_strategy,omitempty"` // SecretStrategyRandom to generate the secret
+	Passphrase     string `json:"passphrase,omitempty"`
+	Privileged     *bool  `json:"privileged,omitempty"`
+	IsActive       *bool  `json:"is_active,omitempty"`
+	PushNow        bool   `json:"push_now,omitempty"`
+	SecretReset    *bool  `json:"secret_reset,omitempty"`
+	OnInvalid      string `json:"on_invalid,omitempty"`
+	Template       string `json:"template,omitempty"`
+	Comment        string `json:"comment,omitempty"`
 }
 
 // UpdateAccountRequest defines the request to update an account
 type UpdateAccountRequest struct {
-	Name       string `json:"username,omitempty"`
-	Secret     string `json:"secret,omitempty"`
-	SecretType string `json:"secret_type,omitempty"`
-	Comment    string `json:"comment,omitempty"`
+	Name        string `json:"username,omitempty"`
+	AccountName string `json:"name,omitempty"`
+	Secret      string `json:"secret,omitempty"`
+	SecretType  string `json:"secret_type,omitempty"`
+	Passphrase  string `json:"passphrase,omitempty"`
+	Privileged  *bool  `json:"privileged,omitempty"`
+	IsActive    *bool  `json:"is_active,omitempty"`
+	PushNow     bool   `json:"push_now,omitempty"`
+	SecretReset *bool  `json:"secret_reset,omitempty"`
+	OnInvalid   string `json:"on_invalid,omitempty"`
+	Comment     string `json:"comment,omitempty"`
 }
 
 // AccountListResponse represents a paginated list of accounts
@@ -66,39 +95,45 @@ type AccountListResponse struct {
 }
 
 // CreateAccount creates a new account
-func (c *Client) CreateAccount(req *CreateAccountRequest) (*Account, error) {
+func (c *Client) CreateAccount(ctx context.Context, req *CreateAccountRequest) (*Account, error) {
 	var result Account
-	err := c.Post("/api/v1/accounts/accounts/", req, &result)
+	err := c.Post(ctx, "/api/v1/accounts/accounts/", req, &result)
 	return &result, err
 }
 
 // GetAccount retrieves an account by ID
-func (c *Client) GetAccount(id string) (*Account, error) {
+func (c *Client) GetAccount(ctx context.Context, id string) (*Account, error) {
 	var result Account
-	err := c.Get(fmt.Sprintf("/api/v1/accounts/accounts/%s/", id), &result)
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/accounts/accounts/%s/", id), &result)
+	return &result, err
+}
+
+// GetAccountSecret retrieves an account together with its secret. Viewing secrets
+// requires the matching permission and may be refused by the server.
+func (c *Client) GetAccountSecret(ctx context.Context, id string) (*AccountSecret, error) {
+	var result AccountSecret
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/accounts/account-secrets/%s/", id), &result)
 	return &result, err
 }
 
-// ListAccounts retrieves a list of accounts
-func (c *Client) ListAccounts(assetID string) ([]Account, error) {
+// ListAccounts retrieves all accounts across every page, optionally filtered by asset
+func (c *Client) ListAccounts(ctx context.Context, assetID string) ([]Account, error) {
 	path := "/api/v1/accounts/accounts/"
 	if assetID != "" {
-		path = fmt.Sprintf("/api/v1/accounts/accounts/?asset=%s", assetID)
+		path = fmt.Sprintf("/api/v1/accounts/accounts/?asset=%s", url.QueryEscape(assetID))
 	}
 
-	var result AccountListResponse
-	err := c.Get(path, &result)
-	return result.Results, err
+	return listAll[Account](ctx, c, path)
 }
 
 // UpdateAccount updates an existing account
-func (c *Client) UpdateAccount(id string, req *UpdateAccountRequest) (*Account, error) {
+func (c *Client) UpdateAccount(ctx context.Context, id string, req *UpdateAccountRequest) (*Account, error) {
 	var result Account
-	err := c.Put(fmt.Sprintf("/api/v1/accounts/accounts/%s/", id), req, &result)
+	err := c.Put(ctx, fmt.Sprintf("/api/v1/accounts/accounts/%s/", id), req, &result)
 	return &result, err
 }
 
 // DeleteAccount deletes an account
-func (c *Client) DeleteAccount(id string) error {
-	return c.Delete(fmt.Sprintf("/api/v1/accounts/accounts/%s/", id), nil)
+func (c *Client) DeleteAccount(ctx context.Context, id string) error {
+	return c.Delete(ctx, fmt.Sprintf("/api/v1/accounts/accounts/%s/", id), nil)
 }
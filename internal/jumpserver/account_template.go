@@ -0,0 +1,115 @@
+package jumpserver
+
+import (
+	"context"
+	"fmt"
+)
+
+// Secret strategies of accounts and account templates
+const (
+	SecretStrategySpecific = "specific" // Use the given secret
+	SecretStrategyRandom   = "random"   // Let JumpServer generate the secret
+)
+
+// PasswordRules describes the passwords generated for the random secret strategy
+type PasswordRules struct {
+	Length         int    `json:"length"`
+	Lowercase      bool   `json:"lowercase"`
+	Uppercase      bool   `json:"uppercase"`
+	Digit          bool   `json:"digit"`
+	Symbol         bool   `json:"symbol"`
+	ExcludeSymbols string `json:"exclude_symbols"`
+}
+
+// AccountTemplate represents a JumpServer account template
+type AccountTemplate struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Username       string         `json:"username"`
+	SecretType     interface{}    `json:"secret_type"`     // Can be string or object {"value":"", "label":""}
+	SecretStrategy interface{}    `json:"secret_strategy"` // Can be string or object {"value":"", "label":""}
+	PasswordRules  *PasswordRules `json:"password_rules,omitempty"`
+	Privileged     bool           `json:"privileged"`
+	SuFrom         interface{}    `json:"su_from"` // Can be null, string ID or object with id
+	AutoPush       bool           `json:"auto_push"`
+	Platforms      []interface{}  `json:"platforms"` // Can be number array or object array
+	IsActive       bool           `json:"is_active"`
+	Comment        string         `json:"comment,omitempty"`
+	Created        string         `json:"date_created,omitempty"`
+	Updated        string         `json:"date_updated,omitempty"`
+}
+
+// GetSecretTypeValue returns the secret_type value as string
+func (t *AccountTemplate) GetSecretTypeValue() string {
+	return choiceValue(t.SecretType)
+}
+
+// GetSecretStrategyValue returns the secret_strategy value as string
+func (t *AccountTemplate) GetSecretStrategyValue() string {
+	return choiceValue(t.SecretStrategy)
+}
+
+// GetSuFromID returns the ID of the template to switch from, or "" when there is none
+func (t *AccountTemplate) GetSuFromID() string {
+	return objectID(t.SuFrom)
+}
+
+// GetPlatformIDs extracts platform IDs from platforms array
+func (t *AccountTemplate) GetPlatformIDs() []int64 {
+	ids := make([]int64, 0, len(t.Platforms))
+	for _, p := range t.Platforms {
+		switch v := p.(type) {
+		case float64:
+			ids = append(ids, int64(v))
+		case map[string]interface{}:
+			if id, ok := v["id"].(float64); ok {
+				ids = append(ids, int64(id))
+			}
+		}
+	}
+	return ids
+}
+
+// AccountTemplateRequest defines the request to create or update an account template
+type AccountTemplateRequest struct {
+	Name           string         `json:"name"`
+	Username       string         `json:"username"`
+	SecretType     string         `json:"secret_type"`
+	SecretStrategy string         `json:"secret_strategy"`
+	Secret         string         `json:"secret,omitempty"`
+	Passphrase     string         `json:"passphrase,omitempty"`
+	PasswordRules  *PasswordRules `json:"password_rules,omitempty"`
+	Privileged     bool           `json:"privileged"`
+	SuFrom         *string        `json:"su_from"` // Always sent so removing it clears the template
+	AutoPush       bool           `json:"auto_push"`
+	Platforms      []int64        `json:"platforms"`
+	IsActive       bool           `json:"is_active"`
+	Comment        string         `json:"comment"`
+}
+
+// CreateAccountTemplate creates a new account template
+func (c *Client) CreateAccountTemplate(ctx context.Context, req *AccountTemplateRequest) (*AccountTemplate, error) {
+	var result AccountTemplate
+	err := c.Post(ctx, "/api/v1/accounts/account-templates/", req, &result)
+	return &result, err
+}
+
+// GetAccountTemplate retrieves an account template by ID
+func (c *Client) GetAccountTemplate(ctx context.Context, id string) (*AccountTemplate, error) {
+	var result AccountTemplate
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/accounts/account-templates/%s/", id), &result)
+	return &result, err
+}
+
+// UpdateAccountTemplate updates an existing account template. The secret is only
+// changed when one is given.
+func (c *Client) UpdateAccountTemplate(ctx context.Context, id string, req *AccountTemplateRequest) (*AccountTemplate, error) {
+	var result AccountTemplate
+	err := c.Patch(ctx, fmt.Sprintf("/api/v1/accounts/account-templates/%s/", id), req, &result)
+	return &result, err
+}
+
+// DeleteAccountTemplate deletes an account template
+func (c *Client) DeleteAccountTemplate(ctx context.Context, id string) error {
+	return c.Delete(ctx, fmt.Sprintf("/api/v1/accounts/account-templates/%s/", id), nil)
+}
@@ -0,0 +1,53 @@
+package jumpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateAccountTemplateSendsPatch(t *testing.T) {
+	var method, path string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("could not decode request body: %s", err)
+		}
+		w.Write([]byte(`{"id":"tpl1","name":"deploy","secret_strategy":{"value":"specific","label":"Specific"},"su_from":null,"platforms":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL})
+
+	template, err := client.UpdateAccountTemplate(context.Background(), "tpl1", &AccountTemplateRequest{
+		Name:           "deploy",
+		Username:       "deploy",
+		SecretType:     "password",
+		SecretStrategy: SecretStrategySpecific,
+		Platforms:      []int64{},
+	})
+	if err != nil {
+		t.Fatalf("UpdateAccountTemplate returned error: %s", err)
+	}
+	if method != http.MethodPatch || path != "/api/v1/accounts/account-templates/tpl1/" {
+		t.Errorf("unexpected request %s %s", method, path)
+	}
+
+	// Without a new secret the stored one is kept, while su_from and platforms are
+	// always sent so removing them clears the template
+	if _, ok := body["secret"]; ok {
+		t.Errorf("expected no secret to be sent, got %v", body["secret"])
+	}
+	if suFrom, ok := body["su_from"]; !ok || suFrom != nil {
+		t.Errorf("expected su_from to be sent as null, got %v", body)
+	}
+	if platforms, ok := body["platforms"].([]interface{}); !ok || len(platforms) != 0 {
+		t.Errorf("expected an empty platform list, got %v", body["platforms"])
+	}
+	if template.GetSecretStrategyValue() != SecretStrategySpecific || template.GetSuFromID() != "" {
+		t.Errorf("unexpected template %+v", template)
+	}
+}
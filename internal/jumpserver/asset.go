@@ -1,19 +1,133 @@
 package jumpserver
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
+
+// Asset categories supported by JumpServer
+const (
+	AssetCategoryHost     = "host"
+	AssetCategoryDatabase = "database"
+	AssetCategoryWeb      = "web"
+	AssetCategoryDevice   = "device"
+	AssetCategoryCloud    = "cloud"
+	AssetCategoryCustom   = "custom"
+)
+
+// AssetCategories lists every asset category in API order
+var AssetCategories = []string{
+	AssetCategoryHost,
+	AssetCategoryDatabase,
+	AssetCategoryWeb,
+	AssetCategoryDevice,
+	AssetCategoryCloud,
+	AssetCategoryCustom,
+}
+
+// assetCollections maps an asset category to its API collection
+var assetCollections = map[string]string{
+	AssetCategoryHost:     "hosts",
+	AssetCategoryDatabase: "databases",
+	AssetCategoryWeb:      "webs",
+	AssetCategoryDevice:   "devices",
+	AssetCategoryCloud:    "clouds",
+	AssetCategoryCustom:   "customs",
+}
+
+// assetPath returns the API path for an asset category. An empty category uses
+// the generic assets endpoint, which serves every category without category-specific fields.
+func assetPath(category string) (string, error) {
+	if category == "" {
+		return "/api/v1/assets/assets/", nil
+	}
+
+	collection, ok := assetCollections[category]
+	if !ok {
+		return "", fmt.Errorf("unsupported asset category: %s", category)
+	}
+	return fmt.Sprintf("/api/v1/assets/%s/", collection), nil
+}
 
 // Asset represents a JumpServer asset
 type Asset struct {
-	ID       string   `json:"id"`
-	Name     string   `json:"name"`
-	Address  string   `json:"address"` // Request field name
-	Addrs    string   `json:"addrs"`   // Response field name
-	Platform Platform `json:"platform"`
-	Nodes    []Node   `json:"nodes,omitempty"`
-	IsActive bool     `json:"is_active"`
-	Comment  string   `json:"comment,omitempty"`
-	Created  string   `json:"date_created,omitempty"`
-	Updated  string   `json:"date_updated,omitempty"`
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Address   string        `json:"address"` // Request field name
+	Addrs     string        `json:"addrs"`   // Response field name
+	Platform  Platform      `json:"platform"`
+	Category  interface{}   `json:"category,omitempty"` // Can be string or object {"value":"", "label":""}
+	Protocols []Protocol    `json:"protocols,omitempty"`
+	Nodes     []Node        `json:"nodes,omitempty"`
+	Labels    []interface{} `json:"labels,omitempty"` // Can be "name:value" strings or label objects
+	IsActive  bool          `json:"is_active"`
+	Comment   string        `json:"comment,omitempty"`
+	Created   string        `json:"date_created,omitempty"`
+	Updated   string        `json:"date_updated,omitempty"`
+	*DatabaseSettings
+	*WebSettings
+}
+
+// DatabaseSettings holds the fields specific to database assets
+type DatabaseSettings struct {
+	DBName           string `json:"db_name"`
+	UseSSL           *bool  `json:"use_ssl,omitempty"`
+	CACert           string `json:"ca_cert,omitempty"`
+	ClientCert       string `json:"client_cert,omitempty"`
+	ClientKey        string `json:"client_key,omitempty"`
+	AllowInvalidCert *bool  `json:"allow_invalid_cert,omitempty"`
+}
+
+// WebSettings holds the autofill settings specific to web assets
+type WebSettings struct {
+	Autofill         interface{} `json:"autofill,omitempty"` // Can be string or object {"value":"", "label":""}
+	UsernameSelector string      `json:"username_selector,omitempty"`
+	PasswordSelector string      `json:"password_selector,omitempty"`
+	SubmitSelector   string      `json:"submit_selector,omitempty"`
+}
+
+// GetCategoryValue returns the category value as string
+func (a *Asset) GetCategoryValue() string {
+	switch v := a.Category.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if val, ok := v["value"].(string); ok {
+			return val
+		}
+	}
+	return a.Platform.GetCategoryValue()
+}
+
+// GetLabelKeys returns the asset labels in name:value form
+func (a *Asset) GetLabelKeys() []string {
+	var keys []string
+	for _, l := range a.Labels {
+		switch v := l.(type) {
+		case string:
+			keys = append(keys, v)
+		case map[string]interface{}:
+			name, _ := v["name"].(string)
+			value, _ := v["value"].(string)
+			if name != "" {
+				keys = append(keys, LabelKey(name, value))
+			}
+		}
+	}
+	return keys
+}
+
+// GetAutofillValue returns the autofill mode as string
+func (w *WebSettings) GetAutofillValue() string {
+	switch v := w.Autofill.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if val, ok := v["value"].(string); ok {
+			return val
+		}
+	}
+	return ""
 }
 
 // PlatformRequest represents platform in request
@@ -23,11 +137,19 @@ type PlatformRequest struct {
 
 // Platform represents a platform (Linux, Windows, etc.)
 type Platform struct {
-	ID          interface{} `json:"id"`
-	Name        string      `json:"name"`
-	DisplayName string      `json:"display_name"`
-	Type        interface{} `json:"type"`     // Can be string or object {"value":"", "label":""}
-	Category    interface{} `json:"category"` // Can be string or object {"value":"", "label":""}
+	ID            interface{}         `json:"id"`
+	Name          string              `json:"name"`
+	DisplayName   string              `json:"display_name"`
+	Type          interface{}         `json:"type"`              // Can be string or object {"value":"", "label":""}
+	Category      interface{}         `json:"category"`          // Can be string or object {"value":"", "label":""}
+	Charset       interface{}         `json:"charset,omitempty"` // Can be string or object {"value":"", "label":""}
+	DomainEnabled bool                `json:"domain_enabled"`
+	SuEnabled     bool                `json:"su_enabled"`
+	SuMethod      interface{}         `json:"su_method,omitempty"` // Can be string or object {"value":"", "label":""}
+	Protocols     []PlatformProtocol  `json:"protocols,omitempty"`
+	Automation    *PlatformAutomation `json:"automation,omitempty"`
+	Internal      bool                `json:"internal"`
+	Comment       string              `json:"comment,omitempty"`
 }
 
 // GetID returns the platform ID as string
@@ -72,27 +194,38 @@ func (p *Platform) GetCategoryValue() string {
 
 // Protocol represents a protocol configuration
 type Protocol struct {
-	Name string `json:"name"`
-	Port int    `json:"port"`
+	Name    string           `json:"name"`
+	Port    int              `json:"port"`
+	Setting *ProtocolSetting `json:"setting,omitempty"`
+}
+
+// ProtocolSetting holds per-protocol connection options
+type ProtocolSetting struct {
+	SFTPEnabled *bool  `json:"sftp_enabled,omitempty"`
+	SFTPHome    string `json:"sftp_home,omitempty"`
+	Console     *bool  `json:"console,omitempty"`
+	Security    string `json:"security,omitempty"`
 }
 
 // AssetAccount represents an asset account in create/update asset request
 type AssetAccount struct {
 	Name        string `json:"name"`
 	Username    string `json:"username"`
-	Secret      string `json:"secret"`
+	Secret      string `json:"secret,omitempty"`
 	SecretType  string `json:"secret_type"`
 	Privileged  bool   `json:"privileged"`
 	PushNow     bool   `json:"push_now"`
 	SecretReset bool   `json:"secret_reset"`
 	OnInvalid   string `json:"on_invalid"`
 	IsActive    bool   `json:"is_active"`
+	Template    string `json:"template,omitempty"`
 }
 
 // Node represents an organization node
 type Node struct {
 	ID       string `json:"id"`
-	FullName string `json:"full_value"` // Changed from full_name to full_value to match API
+	Key      string `json:"key,omitempty"` // Tree position, e.g. "1:3:5"
+	FullName string `json:"full_value"`    // Changed from full_name to full_value to match API
 	Value    string `json:"value"`
 	Name     string `json:"name"`
 	Weight   int    `json:"weight"`
@@ -109,6 +242,8 @@ type CreateAssetRequest struct {
 	Labels    []string        `json:"labels,omitempty"`
 	IsActive  bool            `json:"is_active"`
 	Comment   string          `json:"comment,omitempty"`
+	*DatabaseSettings
+	*WebSettings
 }
 
 // NodeRequest represents node in request
@@ -124,9 +259,11 @@ type UpdateAssetRequest struct {
 	Nodes     []NodeRequest   `json:"nodes,omitempty"`
 	Protocols []Protocol      `json:"protocols,omitempty"`
 	Accounts  []AssetAccount  `json:"accounts,omitempty"`
-	Labels    []string        `json:"labels,omitempty"`
+	Labels    *[]string       `json:"labels,omitempty"` // nil leaves the labels untouched, an empty list clears them
 	IsActive  *bool           `json:"is_active,omitempty"`
 	Comment   string          `json:"comment,omitempty"`
+	*DatabaseSettings
+	*WebSettings
 }
 
 // AssetListResponse represents a paginated list of assets
@@ -137,35 +274,55 @@ type AssetListResponse struct {
 	Results  []Asset `json:"results"`
 }
 
-// CreateAsset creates a new asset
-func (c *Client) CreateAsset(req *CreateAssetRequest) (*Asset, error) {
+// CreateAsset creates a new asset in the given category
+func (c *Client) CreateAsset(ctx context.Context, category string, req *CreateAssetRequest) (*Asset, error) {
+	path, err := assetPath(category)
+	if err != nil {
+		return nil, err
+	}
+
 	var result Asset
-	err := c.Post("/api/v1/assets/hosts/", req, &result)
+	err = c.Post(ctx, path, req, &result)
 	return &result, err
 }
 
-// GetAsset retrieves an asset by ID
-func (c *Client) GetAsset(id string) (*Asset, error) {
+// GetAsset retrieves an asset by ID. Pass an empty category when it is not known;
+// category-specific fields are then omitted from the result.
+func (c *Client) GetAsset(ctx context.Context, category, id string) (*Asset, error) {
+	path, err := assetPath(category)
+	if err != nil {
+		return nil, err
+	}
+
 	var result Asset
-	err := c.Get(fmt.Sprintf("/api/v1/assets/hosts/%s/", id), &result)
+	err = c.Get(ctx, path+id+"/", &result)
 	return &result, err
 }
 
-// ListAssets retrieves a list of assets
-func (c *Client) ListAssets() ([]Asset, error) {
-	var result AssetListResponse
-	err := c.Get("/api/v1/assets/hosts/", &result)
-	return result.Results, err
+// ListAssets retrieves all assets in a category across every page. An empty
+// category lists assets of every category.
+func (c *Client) ListAssets(ctx context.Context, category string) ([]Asset, error) {
+	path, err := assetPath(category)
+	if err != nil {
+		return nil, err
+	}
+
+	return listAll[Asset](ctx, c, path)
 }
 
-// UpdateAsset updates an existing asset
-func (c *Client) UpdateAsset(id string, req *UpdateAssetRequest) (*Asset, error) {
+// UpdateAsset updates an existing asset in the given category
+func (c *Client) UpdateAsset(ctx context.Context, category, id string, req *UpdateAssetRequest) (*Asset, error) {
+	path, err := assetPath(category)
+	if err != nil {
+		return nil, err
+	}
+
 	var result Asset
-	err := c.Put(fmt.Sprintf("/api/v1/assets/hosts/%s/", id), req, &result)
+	err = c.Put(ctx, path+id+"/", req, &result)
 	return &result, err
 }
 
-// DeleteAsset deletes an asset
-func (c *Client) DeleteAsset(id string) error {
-	return c.Delete(fmt.Sprintf("/api/v1/assets/hosts/%s/", id), nil)
+// DeleteAsset deletes an asset of any category
+func (c *Client) DeleteAsset(ctx context.Context, id string) error {
+	return c.Delete(ctx, fmt.Sprintf("/api/v1/assets/assets/%s/", id), nil)
 }
@@ -0,0 +1,25 @@
+package jumpserver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestUpdateAssetRequestLabels(t *testing.T) {
+	data, err := json.Marshal(UpdateAssetRequest{Name: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"labels"`) {
+		t.Errorf("expected unset labels to be left out, got %s", data)
+	}
+
+	data, err = json.Marshal(UpdateAssetRequest{Name: "web", Labels: &[]string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"labels":[]`) {
+		t.Errorf("expected an empty label list to be sent, got %s", data)
+	}
+}
@@ -2,6 +2,7 @@ package jumpserver
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"crypto/tls"
@@ -12,6 +13,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Config holds the JumpServer client configuration
@@ -22,6 +25,10 @@ type Config struct {
 	OrgID              string
 	Timeout            time.Duration
 	InsecureSkipVerify bool
+	PageSize           int
+	MaxRetries         int
+	RetryMinBackoff    time.Duration
+	RetryMaxBackoff    time.Duration
 }
 
 // Client represents a JumpServer API client
@@ -37,7 +44,19 @@ func NewClient(config *Config) *Client {
 	}
 
 	if config.OrgID == "" {
-		config.OrgID = "00000000-0000-0000-0000-000000000000"
+		config.OrgID = DefaultOrgID
+	}
+
+	if config.PageSize <= 0 {
+		config.PageSize = DefaultPageSize
+	}
+
+	if config.RetryMinBackoff <= 0 {
+		config.RetryMinBackoff = DefaultRetryMinBackoff
+	}
+
+	if config.RetryMaxBackoff <= 0 {
+		config.RetryMaxBackoff = max(DefaultRetryMaxBackoff, config.RetryMinBackoff)
 	}
 
 	return &Client{
@@ -56,8 +75,8 @@ func (c *Client) SignRequest(req *http.Request) error {
 	date := time.Now().UTC().Format(http.TimeFormat)
 	req.Header.Set("Date", date)
 
-	// Build string to sign
-	requestTarget := "(request-target): " + strings.ToLower(req.Method) + " " + req.URL.Path
+	// Build string to sign; the request target includes the query string
+	requestTarget := "(request-target): " + strings.ToLower(req.Method) + " " + req.URL.RequestURI()
 	stringToSign := requestTarget + "\ndate: " + date
 
 	// Create HMAC-SHA256 signature
@@ -70,152 +89,165 @@ func (c *Client) SignRequest(req *http.Request) error {
 		c.config.KeyID, signature)
 	req.Header.Set("Authorization", authHeader)
 
-	// Set organization header
-	if c.config.OrgID != "" {
+	// Set organization header, letting the request context override the configured organization
+	if orgID := orgIDFromContext(req.Context()); orgID != "" {
+		req.Header.Set("X-JMS-ORG", orgID)
+	} else if c.config.OrgID != "" {
 		req.Header.Set("X-JMS-ORG", c.config.OrgID)
 	}
 
 	return nil
 }
 
-// DoRequest executes an HTTP request with authentication
-func (c *Client) DoRequest(method, path string, body interface{}, result interface{}) error {
-	var reqBody io.Reader
+// DoRequest executes an HTTP request with authentication, retrying transient failures
+func (c *Client) DoRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	ctx = newLogContext(ctx)
+
 	var jsonData []byte
 
 	if body != nil {
 		var err error
 		jsonData, err = json.Marshal(body)
 		if err != nil {
-			fmt.Printf("[DEBUG] [CLIENT] Failed to marshal request body: %v\n", err)
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	url := c.config.Endpoint + path
-	fmt.Printf("[DEBUG] [CLIENT] =========================================\n")
-	fmt.Printf("[DEBUG] [CLIENT] REQUEST START\n")
-	fmt.Printf("[DEBUG] [CLIENT] Method: %s\n", method)
-	fmt.Printf("[DEBUG] [CLIENT] URL: %s\n", url)
-	fmt.Printf("[DEBUG] [CLIENT] Path: %s\n", path)
-	if body != nil {
-		fmt.Printf("[DEBUG] [CLIENT] Request Body:\n%s\n", string(jsonData))
-	} else {
-		fmt.Printf("[DEBUG] [CLIENT] Request Body: (none)\n")
+	// Only idempotent requests are retried unless the caller opted in
+	maxRetries := 0
+	if isIdempotent(method) || retryNonIdempotent(ctx) {
+		maxRetries = c.config.MaxRetries
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		fmt.Printf("[DEBUG] [CLIENT] Failed to create request: %v\n", err)
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+	var resp *http.Response
+	var respBody []byte
+	var err error
+	for attempt := 1; ; attempt++ {
+		tflog.SubsystemDebug(ctx, logSubsystem, "Sending JumpServer API request", map[string]any{
+			"method":  method,
+			"path":    path,
+			"attempt": attempt,
+		})
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
+		resp, respBody, err = c.doAttempt(ctx, method, path, jsonData)
+		if attempt > maxRetries || !shouldRetry(ctx, resp, err) {
+			break
+		}
 
-	if err := c.SignRequest(req); err != nil {
-		fmt.Printf("[DEBUG] [CLIENT] Failed to sign request: %v\n", err)
-		return fmt.Errorf("failed to sign request: %w", err)
-	}
+		wait := c.retryBackoff(attempt, resp)
+		fields := map[string]any{
+			"method":  method,
+			"path":    path,
+			"attempt": attempt,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+		}
+		tflog.SubsystemWarn(ctx, logSubsystem, "Retrying JumpServer API request after transient failure", fields)
 
-	fmt.Printf("[DEBUG] [CLIENT] Request Headers:\n")
-	for key, values := range req.Header {
-		for _, value := range values {
-			fmt.Printf("[DEBUG] [CLIENT]   %s: %s\n", key, value)
+		if err := sleepContext(ctx, wait); err != nil {
+			return fmt.Errorf("request cancelled while waiting to retry: %w", err)
 		}
 	}
-
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		fmt.Printf("[DEBUG] [CLIENT] Failed to execute request: %v\n", err)
-		fmt.Printf("[DEBUG] [CLIENT] =========================================\n")
-		return fmt.Errorf("failed to execute request: %w", err)
+		tflog.SubsystemError(ctx, logSubsystem, "JumpServer API request failed", map[string]any{
+			"method": method,
+			"path":   path,
+			"error":  err.Error(),
+		})
+		return err
 	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		fmt.Printf("[DEBUG] [CLIENT] Failed to read response body: %v\n", err)
-		fmt.Printf("[DEBUG] [CLIENT] =========================================\n")
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	fmt.Printf("[DEBUG] [CLIENT] Response Status: %d %s\n", resp.StatusCode, resp.Status)
-	fmt.Printf("[DEBUG] [CLIENT] Response Headers:\n")
-	for key, values := range resp.Header {
-		for _, value := range values {
-			fmt.Printf("[DEBUG] [CLIENT]   %s: %s\n", key, value)
-		}
-	}
-	fmt.Printf("[DEBUG] [CLIENT] Response Body:\n%s\n", string(respBody))
-	fmt.Printf("[DEBUG] [CLIENT] RESPONSE END\n")
-	fmt.Printf("[DEBUG] [CLIENT] =========================================\n")
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return newAPIError(method, path, resp, respBody)
 	}
 
 	if result != nil {
 		if err := json.Unmarshal(respBody, result); err != nil {
-			fmt.Printf("[DEBUG] [CLIENT] Failed to unmarshal response: %v\n", err)
 			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
-		fmt.Printf("[DEBUG] [CLIENT] Unmarshaled Result (type %T): %+v\n", result, result)
 	}
 
 	return nil
 }
 
-// Get performs a GET request
-func (c *Client) Get(path string, result interface{}) error {
-	return c.DoRequest("GET", path, nil, result)
-}
-
-// getRaw performs a GET request and returns raw response bytes
-func (c *Client) getRaw(path string) ([]byte, error) {
-	var result interface{}
-	err := c.DoRequest("GET", path, nil, &result)
+// doAttempt sends a single signed request and returns the response with its body already read
+func (c *Client) doAttempt(ctx context.Context, method, path string, jsonData []byte) (*http.Response, []byte, error) {
+	var reqBody io.Reader
+	if jsonData != nil {
+		reqBody = bytes.NewReader(jsonData)
+	}
 
-	// Extract the raw response from DoRequest
-	// We need to modify DoRequest to support returning raw bytes, or parse from debug
-	// For now, let's make a direct request
-	url := c.config.Endpoint + path
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, c.config.Endpoint+path, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if jsonData != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
 	if err := c.SignRequest(req); err != nil {
-		return nil, fmt.Errorf("failed to sign request: %w", err)
+		return nil, nil, fmt.Errorf("failed to sign request: %w", err)
 	}
 
+	tflog.SubsystemTrace(ctx, logSubsystem, "JumpServer API request details", map[string]any{
+		"method":  method,
+		"url":     req.URL.String(),
+		"headers": redactHeaders(req.Header),
+		"body":    redactBody(jsonData),
+	})
+
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return io.ReadAll(resp.Body)
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received JumpServer API response", map[string]any{
+		"method":      method,
+		"path":        path,
+		"status":      resp.StatusCode,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	tflog.SubsystemTrace(ctx, logSubsystem, "JumpServer API response details", map[string]any{
+		"headers": redactHeaders(resp.Header),
+		"body":    redactBody(respBody),
+	})
+
+	return resp, respBody, nil
+}
+
+// Get performs a GET request
+func (c *Client) Get(ctx context.Context, path string, result interface{}) error {
+	return c.DoRequest(ctx, "GET", path, nil, result)
 }
 
 // Post performs a POST request
-func (c *Client) Post(path string, body, result interface{}) error {
-	return c.DoRequest("POST", path, body, result)
+func (c *Client) Post(ctx context.Context, path string, body, result interface{}) error {
+	return c.DoRequest(ctx, "POST", path, body, result)
 }
 
 // Put performs a PUT request
-func (c *Client) Put(path string, body, result interface{}) error {
-	return c.DoRequest("PUT", path, body, result)
+func (c *Client) Put(ctx context.Context, path string, body, result interface{}) error {
+	return c.DoRequest(ctx, "PUT", path, body, result)
 }
 
 // Delete performs a DELETE request
-func (c *Client) Delete(path string, result interface{}) error {
-	return c.DoRequest("DELETE", path, nil, result)
+func (c *Client) Delete(ctx context.Context, path string, result interface{}) error {
+	return c.DoRequest(ctx, "DELETE", path, nil, result)
+}
+
+// Patch performs a PATCH request
+func (c *Client) Patch(ctx context.Context, path string, body, result interface{}) error {
+	return c.DoRequest(ctx, "PATCH", path, body, result)
 }
@@ -0,0 +1,145 @@
+package jumpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError describes a non-2xx response returned by the JumpServer API
+type APIError struct {
+	StatusCode  int
+	Method      string
+	Path        string
+	RequestID   string
+	Detail      string
+	FieldErrors map[string][]string
+	Body        string
+}
+
+// Error implements the error interface. The body is redacted because validation
+// errors may echo submitted secrets and the message ends up in diagnostics and logs.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, redactBody([]byte(e.Body)))
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return msg
+}
+
+// newAPIError builds an APIError from a failed response, decoding JumpServer's
+// {"detail": "..."} and {"field": ["message", ...]} error formats
+func newAPIError(method, path string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Path:       path,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Body:       string(body),
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return apiErr
+	}
+
+	for key, value := range payload {
+		switch key {
+		case "detail":
+			apiErr.Detail = errorMessages(value)[0]
+		case "code", "error":
+			// Error codes carry no attribute information
+		default:
+			if apiErr.FieldErrors == nil {
+				apiErr.FieldErrors = make(map[string][]string)
+			}
+			apiErr.FieldErrors[key] = errorMessages(value)
+		}
+	}
+
+	return apiErr
+}
+
+// errorMessages flattens a DRF error value into a list of messages
+func errorMessages(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var messages []string
+		for _, item := range v {
+			messages = append(messages, errorMessages(item)...)
+		}
+		if len(messages) > 0 {
+			return messages
+		}
+	case map[string]interface{}:
+		// Nested serializers report errors per sub-field
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var messages []string
+		for _, key := range keys {
+			for _, msg := range errorMessages(v[key]) {
+				messages = append(messages, key+": "+msg)
+			}
+		}
+		if len(messages) > 0 {
+			return messages
+		}
+	}
+
+	data, _ := json.Marshal(value)
+	return []string{strings.TrimSpace(string(data))}
+}
+
+// NotFoundError reports that a lookup by name or path matched no object
+type NotFoundError struct {
+	Kind string
+	Name string
+}
+
+// Error implements the error interface
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.Kind, e.Name)
+}
+
+// AsAPIError returns the APIError wrapped in err, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// hasStatus reports whether err is an APIError with the given status code
+func hasStatus(err error, status int) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == status
+}
+
+// IsNotFound reports whether err is a 404 response or a lookup that matched nothing
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	return hasStatus(err, http.StatusNotFound) || errors.As(err, &notFound)
+}
+
+// IsConflict reports whether err is a 409 response
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsValidation reports whether err is a 400 or 422 response rejecting the request content
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest) || hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+// IsUnauthorized reports whether err is a 401 or 403 response
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized) || hasStatus(err, http.StatusForbidden)
+}
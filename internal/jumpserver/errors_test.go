@@ -0,0 +1,51 @@
+package jumpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDoRequestReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"name":["This field must be unique."],"accounts":[{"secret":["Too short."]}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL})
+
+	_, err := client.CreateAsset(context.Background(), AssetCategoryHost, &CreateAssetRequest{Name: "web"})
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if !IsValidation(err) || IsNotFound(err) {
+		t.Errorf("expected a validation error, got status %d", apiErr.StatusCode)
+	}
+	if apiErr.Method != "POST" || apiErr.RequestID != "req-1" {
+		t.Errorf("unexpected method %q or request ID %q", apiErr.Method, apiErr.RequestID)
+	}
+	if got := apiErr.FieldErrors["name"]; len(got) != 1 || got[0] != "This field must be unique." {
+		t.Errorf("unexpected name errors: %v", got)
+	}
+	if got := apiErr.FieldErrors["accounts"]; len(got) != 1 || got[0] != "secret: Too short." {
+		t.Errorf("unexpected accounts errors: %v", got)
+	}
+}
+
+func TestAPIErrorRedactsSecrets(t *testing.T) {
+	err := &APIError{StatusCode: 400, Body: `{"secret":["hunter2 is too short."],"name":["This field is required."]}`}
+
+	msg := err.Error()
+	if strings.Contains(msg, "hunter2") {
+		t.Errorf("expected the secret to be redacted, got %s", msg)
+	}
+	if !strings.Contains(msg, "This field is required.") {
+		t.Errorf("expected other field errors to be kept, got %s", msg)
+	}
+}
@@ -0,0 +1,106 @@
+package jumpserver
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Label represents a JumpServer label, a name:value pair attached to resources
+type Label struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Color    string `json:"color,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	ResCount int    `json:"res_count,omitempty"`
+	Created  string `json:"date_created,omitempty"`
+	Updated  string `json:"date_updated,omitempty"`
+}
+
+// Key returns the label in name:value form
+func (l *Label) Key() string {
+	return LabelKey(l.Name, l.Value)
+}
+
+// LabelKey joins a label name and value into name:value form
+func LabelKey(name, value string) string {
+	return name + ":" + value
+}
+
+// SplitLabelKey splits a name:value label key. The value may itself contain colons.
+func SplitLabelKey(key string) (name, value string, err error) {
+	name, value, ok := strings.Cut(key, ":")
+	if !ok || name == "" || value == "" {
+		return "", "", fmt.Errorf("invalid label %q, expected name:value", key)
+	}
+	return name, value, nil
+}
+
+// CreateLabelRequest defines the request to create a label
+type CreateLabelRequest struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Color   string `json:"color,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// UpdateLabelRequest defines the request to update a label
+type UpdateLabelRequest struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Color   string `json:"color"`
+	Comment string `json:"comment"`
+}
+
+// CreateLabel creates a new label
+func (c *Client) CreateLabel(ctx context.Context, req *CreateLabelRequest) (*Label, error) {
+	var result Label
+	err := c.Post(ctx, "/api/v1/labels/labels/", req, &result)
+	return &result, err
+}
+
+// GetLabel retrieves a label by ID
+func (c *Client) GetLabel(ctx context.Context, id string) (*Label, error) {
+	var result Label
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/labels/labels/%s/", id), &result)
+	return &result, err
+}
+
+// ListLabels retrieves all labels across every page, optionally filtered by name
+func (c *Client) ListLabels(ctx context.Context, name string) ([]Label, error) {
+	path := "/api/v1/labels/labels/"
+	if name != "" {
+		path += "?name=" + url.QueryEscape(name)
+	}
+	return listAll[Label](ctx, c, path)
+}
+
+// GetLabelByKey retrieves a label by its name and value
+func (c *Client) GetLabelByKey(ctx context.Context, name, value string) (*Label, error) {
+	labels, err := c.ListLabels(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range labels {
+		if l.Name == name && l.Value == value {
+			return &l, nil
+		}
+	}
+
+	return nil, &NotFoundError{Kind: "label", Name: LabelKey(name, value)}
+}
+
+// UpdateLabel updates an existing label
+func (c *Client) UpdateLabel(ctx context.Context, id string, req *UpdateLabelRequest) (*Label, error) {
+	var result Label
+	err := c.Put(ctx, fmt.Sprintf("/api/v1/labels/labels/%s/", id), req, &result)
+	return &result, err
+}
+
+// DeleteLabel deletes a label
+func (c *Client) DeleteLabel(ctx context.Context, id string) error {
+	return c.Delete(ctx, fmt.Sprintf("/api/v1/labels/labels/%s/", id), nil)
+}
@@ -0,0 +1,36 @@
+package jumpserver
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSplitLabelKey(t *testing.T) {
+	name, value, err := SplitLabelKey("url:https://example.com")
+	if err != nil {
+		t.Fatalf("SplitLabelKey returned error: %s", err)
+	}
+	if name != "url" || value != "https://example.com" {
+		t.Errorf("expected url and https://example.com, got %q and %q", name, value)
+	}
+
+	for _, key := range []string{"env", ":prod", "env:"} {
+		if _, _, err := SplitLabelKey(key); err == nil {
+			t.Errorf("expected %q to be rejected", key)
+		}
+	}
+}
+
+func TestAssetGetLabelKeys(t *testing.T) {
+	var asset Asset
+	body := `{"id":"a1","labels":["team:payments",{"id":"l1","name":"env","value":"prod","color":""}]}`
+	if err := json.Unmarshal([]byte(body), &asset); err != nil {
+		t.Fatalf("failed to unmarshal asset: %s", err)
+	}
+
+	want := []string{"team:payments", "env:prod"}
+	if got := asset.GetLabelKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,99 @@
+package jumpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem used by the API client. Its level can be
+// set independently with the TF_LOG_PROVIDER_JUMPSERVER_CLIENT environment variable.
+const logSubsystem = "jumpserver_client"
+
+// redactedValue replaces sensitive values in log output
+const redactedValue = "***"
+
+// sensitiveKeys lists header names and JSON keys whose values are never logged
+var sensitiveKeys = []string{
+	"authorization",
+	"secret",
+	"password",
+	"private_key",
+	"passphrase",
+	"key_secret",
+	"token",
+}
+
+// newLogContext sets up the client subsystem logger with secret masking
+func newLogContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_JUMPSERVER_CLIENT"))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, sensitiveKeys...)
+	return ctx
+}
+
+// isSensitiveKey reports whether a header name or JSON key holds a secret
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range sensitiveKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHeaders returns a copy of the headers with sensitive values masked
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for key, values := range header {
+		if isSensitiveKey(key) {
+			redacted[key] = redactedValue
+			continue
+		}
+		redacted[key] = strings.Join(values, ", ")
+	}
+	return redacted
+}
+
+// redactBody returns a JSON body as a string with sensitive values masked.
+// Bodies that are not valid JSON are omitted entirely rather than risk leaking secrets.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "(non-JSON body omitted)"
+	}
+
+	redacted, err := json.Marshal(redactValue(data))
+	if err != nil {
+		return "(body omitted)"
+	}
+	return string(redacted)
+}
+
+// redactValue walks decoded JSON and masks the values of sensitive keys
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				if item != nil && item != "" {
+					v[key] = redactedValue
+				}
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,37 @@
+package jumpserver
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactBodyMasksSecrets(t *testing.T) {
+	body := []byte(`{"username":"root","secret":"s3cr3t","accounts":[{"name":"a","password":"hunter2","private_key":"-----BEGIN"}]}`)
+
+	redacted := redactBody(body)
+
+	for _, leaked := range []string{"s3cr3t", "hunter2", "BEGIN"} {
+		if strings.Contains(redacted, leaked) {
+			t.Errorf("redacted body still contains %q: %s", leaked, redacted)
+		}
+	}
+	if !strings.Contains(redacted, `"username":"root"`) {
+		t.Errorf("redacted body lost non-sensitive fields: %s", redacted)
+	}
+}
+
+func TestRedactHeadersMasksAuthorization(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", `Signature keyId="id",signature="abc"`)
+	header.Set("X-JMS-ORG", "org")
+
+	redacted := redactHeaders(header)
+
+	if redacted["Authorization"] != redactedValue {
+		t.Errorf("expected Authorization to be masked, got %q", redacted["Authorization"])
+	}
+	if redacted["X-Jms-Org"] != "org" {
+		t.Errorf("expected X-JMS-ORG to be kept, got %q", redacted["X-Jms-Org"])
+	}
+}
@@ -1,8 +1,10 @@
 package jumpserver
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"net/url"
+	"strings"
 )
 
 // NodeListResponse represents a paginated list of nodes
@@ -13,32 +15,73 @@ type NodeListResponse struct {
 	Results  []Node  `json:"results"`
 }
 
-// ListNodes retrieves a list of nodes
-func (c *Client) ListNodes() ([]Node, error) {
-	// Try to unmarshal as paginated response first
-	var paginated NodeListResponse
-	resp, err := c.getRaw("/api/v1/assets/nodes/")
+// CreateNodeRequest defines the request to create a node
+type CreateNodeRequest struct {
+	Value string `json:"value"`
+}
+
+// UpdateNodeRequest defines the request to rename a node
+type UpdateNodeRequest struct {
+	Value string `json:"value"`
+}
+
+// ParentKey returns the key of the parent node, or an empty string for an organization root node
+func (n *Node) ParentKey() string {
+	i := strings.LastIndex(n.Key, ":")
+	if i < 0 {
+		return ""
+	}
+	return n.Key[:i]
+}
+
+// IsDescendantOf reports whether the node lies below the node with the given key
+func (n *Node) IsDescendantOf(key string) bool {
+	return strings.HasPrefix(n.Key, key+":")
+}
+
+// CreateNode creates a node below the given parent, or below the organization root when parentID is empty
+func (c *Client) CreateNode(ctx context.Context, parentID string, req *CreateNodeRequest) (*Node, error) {
+	path := "/api/v1/assets/nodes/"
+	if parentID != "" {
+		path = fmt.Sprintf("/api/v1/assets/nodes/%s/children/", parentID)
+	}
+
+	var result Node
+	err := c.Post(ctx, path, req, &result)
+	return &result, err
+}
+
+// GetNode retrieves a node by ID
+func (c *Client) GetNode(ctx context.Context, id string) (*Node, error) {
+	var result Node
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/assets/nodes/%s/", id), &result)
+	return &result, err
+}
+
+// GetNodeByKey retrieves a node by its tree key
+func (c *Client) GetNodeByKey(ctx context.Context, key string) (*Node, error) {
+	nodes, err := listAll[Node](ctx, c, "/api/v1/assets/nodes/?key="+url.QueryEscape(key))
 	if err != nil {
 		return nil, err
 	}
 
-	// Try paginated format first
-	if err := json.Unmarshal(resp, &paginated); err == nil {
-		return paginated.Results, nil
+	for _, n := range nodes {
+		if n.Key == key {
+			return &n, nil
+		}
 	}
 
-	// If that fails, try direct array format
-	var nodes []Node
-	if err := json.Unmarshal(resp, &nodes); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response as paginated or array: %w", err)
-	}
+	return nil, &NotFoundError{Kind: "node", Name: key}
+}
 
-	return nodes, nil
+// ListNodes retrieves all nodes across every page
+func (c *Client) ListNodes(ctx context.Context) ([]Node, error) {
+	return listAll[Node](ctx, c, "/api/v1/assets/nodes/")
 }
 
 // GetNodeByFullName retrieves a node by full name
-func (c *Client) GetNodeByFullName(fullName string) (*Node, error) {
-	nodes, err := c.ListNodes()
+func (c *Client) GetNodeByFullName(ctx context.Context, fullName string) (*Node, error) {
+	nodes, err := c.ListNodes(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -49,5 +92,40 @@ func (c *Client) GetNodeByFullName(fullName string) (*Node, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("node not found: %s", fullName)
+	return nil, &NotFoundError{Kind: "node", Name: fullName}
+}
+
+// UpdateNode renames a node
+func (c *Client) UpdateNode(ctx context.Context, id string, req *UpdateNodeRequest) (*Node, error) {
+	var result Node
+	err := c.Put(ctx, fmt.Sprintf("/api/v1/assets/nodes/%s/", id), req, &result)
+	return &result, err
+}
+
+// MoveNode moves a node, with its subtree, below a new parent
+func (c *Client) MoveNode(ctx context.Context, id, parentID string) error {
+	body := map[string][]string{"nodes": {id}}
+	return c.Put(ctx, fmt.Sprintf("/api/v1/assets/nodes/%s/children/add/", parentID), body, nil)
+}
+
+// DeleteNode deletes a node. JumpServer refuses to delete nodes whose subtree still holds assets.
+func (c *Client) DeleteNode(ctx context.Context, id string) error {
+	return c.Delete(ctx, fmt.Sprintf("/api/v1/assets/nodes/%s/", id), nil)
+}
+
+// ListNodeAssets retrieves the assets attached to a node, including those of its
+// descendants when recursive is set
+func (c *Client) ListNodeAssets(ctx context.Context, id string, recursive bool) ([]Asset, error) {
+	query := url.Values{}
+	query.Set("node_id", id)
+	if recursive {
+		query.Set("all", "1")
+	}
+	return listAll[Asset](ctx, c, "/api/v1/assets/assets/?"+query.Encode())
+}
+
+// RemoveNodeAssets detaches assets from a node without deleting them
+func (c *Client) RemoveNodeAssets(ctx context.Context, id string, assetIDs []string) error {
+	body := map[string][]string{"assets": assetIDs}
+	return c.Put(ctx, fmt.Sprintf("/api/v1/assets/nodes/%s/assets/remove/", id), body, nil)
 }
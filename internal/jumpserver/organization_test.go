@@ -0,0 +1,37 @@
+package jumpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithOrgIDOverridesOrgHeader(t *testing.T) {
+	var orgs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orgs = append(orgs, r.Header.Get("X-JMS-ORG"))
+		w.Write([]byte(`{"id":"u1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL, OrgID: "org-a"})
+
+	ctx := context.Background()
+	if _, err := client.GetUser(ctx, "u1"); err != nil {
+		t.Fatalf("GetUser returned error: %s", err)
+	}
+	if _, err := client.GetUser(WithOrgID(ctx, "org-b"), "u1"); err != nil {
+		t.Fatalf("GetUser returned error: %s", err)
+	}
+	if _, err := client.GetUser(WithOrgID(ctx, ""), "u1"); err != nil {
+		t.Fatalf("GetUser returned error: %s", err)
+	}
+
+	want := []string{"org-a", "org-b", "org-a"}
+	for i := range want {
+		if orgs[i] != want[i] {
+			t.Errorf("request %d: expected org %q, got %q", i, want[i], orgs[i])
+		}
+	}
+}
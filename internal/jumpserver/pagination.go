@@ -0,0 +1,110 @@
+package jumpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize is the number of items requested per page by list calls
+const DefaultPageSize = 100
+
+// pageResponse represents one page of a paginated JumpServer list endpoint
+type pageResponse[T any] struct {
+	Count    int     `json:"count"`
+	Next     *string `json:"next,omitempty"`
+	Previous *string `json:"previous,omitempty"`
+	Results  []T     `json:"results"`
+}
+
+// listAll retrieves every page of a list endpoint and returns the combined results.
+// It follows the "next" link returned by the API and falls back to limit/offset
+// when the server omits it. Endpoints that return a bare JSON array are accepted too.
+// A page that points back to one already fetched is an error rather than an endless loop.
+func listAll[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	var all []T
+	offset := 0
+
+	pagePath, err := withPagination(path, c.config.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	visited := make(map[string]bool)
+	for {
+		if visited[pagePath] {
+			return nil, fmt.Errorf("pagination of %s loops back to %s", path, pagePath)
+		}
+		visited[pagePath] = true
+
+		var raw json.RawMessage
+		if err := c.Get(ctx, pagePath, &raw); err != nil {
+			return nil, err
+		}
+
+		// Some endpoints ignore pagination parameters and return a plain array
+		if trimmed := strings.TrimSpace(string(raw)); strings.HasPrefix(trimmed, "[") {
+			var items []T
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal list response: %w", err)
+			}
+			return append(all, items...), nil
+		}
+
+		var page pageResponse[T]
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal paginated response: %w", err)
+		}
+		all = append(all, page.Results...)
+		offset += len(page.Results)
+
+		switch {
+		case page.Next != nil && *page.Next != "":
+			pagePath, err = c.relativePath(*page.Next)
+			if err != nil {
+				return nil, err
+			}
+		case len(page.Results) > 0 && offset < page.Count:
+			pagePath, err = withPagination(path, c.config.PageSize, offset)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return all, nil
+		}
+	}
+}
+
+// withPagination adds limit and offset query parameters to an API path
+func withPagination(path string, limit, offset int) (string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid list path %q: %w", path, err)
+	}
+
+	query := u.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// relativePath converts an absolute "next" link into a path relative to the configured endpoint
+func (c *Client) relativePath(link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("invalid pagination link %q: %w", link, err)
+	}
+
+	// The endpoint may be mounted below a path prefix, which DoRequest adds back
+	prefix := ""
+	if base, err := url.Parse(c.config.Endpoint); err == nil {
+		prefix = strings.TrimSuffix(base.Path, "/")
+	}
+
+	return strings.TrimPrefix(u.RequestURI(), prefix), nil
+}
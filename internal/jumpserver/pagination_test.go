@@ -0,0 +1,85 @@
+package jumpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestListAllFollowsNextLinks(t *testing.T) {
+	const total = 5
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var results string
+		for i := offset; i < offset+limit && i < total; i++ {
+			if results != "" {
+				results += ","
+			}
+			results += fmt.Sprintf(`{"id":"user-%d","username":"u%d"}`, i, i)
+		}
+
+		next := "null"
+		if offset+limit < total {
+			next = fmt.Sprintf(`"%s/api/v1/users/users/?limit=%d&offset=%d"`, server.URL, limit, offset+limit)
+		}
+
+		fmt.Fprintf(w, `{"count":%d,"next":%s,"previous":null,"results":[%s]}`, total, next, results)
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL, PageSize: 2})
+
+	users, err := client.ListUsers(context.Background())
+	if err != nil {
+		t.Fatalf("ListUsers returned error: %s", err)
+	}
+	if len(users) != total {
+		t.Fatalf("expected %d users, got %d", total, len(users))
+	}
+	if users[total-1].Username != "u4" {
+		t.Errorf("expected last user u4, got %s", users[total-1].Username)
+	}
+}
+
+func TestListAllAcceptsBareArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"n1","full_value":"/Default"},{"id":"n2","full_value":"/Default/Prod"}]`)
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL})
+
+	node, err := client.GetNodeByFullName(context.Background(), "/Default/Prod")
+	if err != nil {
+		t.Fatalf("GetNodeByFullName returned error: %s", err)
+	}
+	if node.ID != "n2" {
+		t.Errorf("expected node n2, got %s", node.ID)
+	}
+}
+
+func TestListAllStopsOnRepeatedNextLink(t *testing.T) {
+	requests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"count":10,"next":"%s/api/v1/users/users/?limit=2&offset=2","results":[{"id":"u%d"}]}`, server.URL, requests)
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL, PageSize: 2})
+
+	if _, err := client.ListUsers(context.Background()); err == nil {
+		t.Fatal("expected an error for a repeated next link")
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests before the loop is detected, got %d", requests)
+	}
+}
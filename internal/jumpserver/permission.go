@@ -1,6 +1,91 @@
 package jumpserver
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
+
+// Account aliases accepted in the accounts of a permission
+const (
+	AccountAliasAll   = "@ALL"   // Every account on the asset
+	AccountAliasSpec  = "@SPEC"  // Only the accounts listed by name
+	AccountAliasInput = "@INPUT" // Credentials typed in manually at login
+	AccountAliasUser  = "@USER"  // Same username and password as the JumpServer user
+	AccountAliasAnon  = "@ANON"  // No credentials, for assets that do not need them
+)
+
+// Permission actions. JumpServer stores every permission as a combination of these.
+const (
+	ActionConnect  = "connect"
+	ActionUpload   = "upload"
+	ActionDownload = "download"
+	ActionCopy     = "copy"
+	ActionPaste    = "paste"
+	ActionDelete   = "delete"
+	ActionShare    = "share"
+)
+
+// Actions lists the atomic actions in the order JumpServer defines them. Action n is
+// stored as bit n of the actions bitmask.
+var Actions = []string{ActionConnect, ActionUpload, ActionDownload, ActionCopy, ActionPaste, ActionDelete, ActionShare}
+
+// CompositeActions maps the action groups JumpServer offers to the actions they contain
+var CompositeActions = map[string][]string{
+	"all":       Actions,
+	"transfer":  {ActionUpload, ActionDownload, ActionDelete},
+	"clipboard": {ActionCopy, ActionPaste},
+}
+
+// legacyActions maps action names used by older JumpServer versions to their current form
+var legacyActions = map[string][]string{
+	"upload_file":          {ActionUpload},
+	"download_file":        {ActionDownload},
+	"delete_file":          {ActionDelete},
+	"updownload":           {ActionUpload, ActionDownload},
+	"clipboard_copy":       {ActionCopy},
+	"clipboard_paste":      {ActionPaste},
+	"clipboard_copy_paste": {ActionCopy, ActionPaste},
+}
+
+// ExpandActions resolves composite and legacy action names into atomic actions, sorted
+// in JumpServer order without duplicates. Names it does not recognize are returned
+// separately.
+func ExpandActions(names []string) (actions []string, unknown []string) {
+	set := make(map[string]bool, len(Actions))
+	for _, name := range names {
+		switch {
+		case CompositeActions[name] != nil:
+			for _, a := range CompositeActions[name] {
+				set[a] = true
+			}
+		case legacyActions[name] != nil:
+			for _, a := range legacyActions[name] {
+				set[a] = true
+			}
+		case isAction(name):
+			set[name] = true
+		default:
+			unknown = append(unknown, name)
+		}
+	}
+
+	for _, a := range Actions {
+		if set[a] {
+			actions = append(actions, a)
+		}
+	}
+	return actions, unknown
+}
+
+// isAction reports whether the name is an atomic action
+func isAction(name string) bool {
+	for _, a := range Actions {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
 
 // Permission represents a JumpServer permission
 type Permission struct {
@@ -10,7 +95,13 @@ type Permission struct {
 	UserGroups  []string      `json:"user_groups"`
 	Assets      []interface{} `json:"assets"` // Can be string array or object array
 	AssetGroups []string      `json:"asset_groups"`
-	Actions     []interface{} `json:"actions"` // Can be string array or object array
+	Nodes       []interface{} `json:"nodes"`     // Can be string array or object array
+	Accounts    []string      `json:"accounts"`  // Account aliases such as @ALL, or account names with @SPEC
+	Protocols   []string      `json:"protocols"` // Protocol names, or "all"
+	Actions     []interface{} `json:"actions"`   // Can be string array or object array
+	IsActive    bool          `json:"is_active"`
+	DateStart   string        `json:"date_start,omitempty"`
+	DateExpired string        `json:"date_expired,omitempty"`
 	Comment     string        `json:"comment,omitempty"`
 	Created     string        `json:"date_created,omitempty"`
 	Updated     string        `json:"date_updated,omitempty"`
@@ -60,22 +151,50 @@ func (p *Permission) GetAssetIDs() []string {
 	return ids
 }
 
+// GetNodeIDs extracts node IDs from nodes array
+func (p *Permission) GetNodeIDs() []string {
+	return objectIDs(p.Nodes)
+}
+
 // GetActionValues extracts action values from actions array
 func (p *Permission) GetActionValues() []string {
+	return actionValues(p.Actions)
+}
+
+// actionValues extracts action names from an actions array. Depending on the version,
+// the API renders actions as names, {"value", "label"} objects or bitmasks; bitmasks
+// are decoded into action names.
+func actionValues(actions []interface{}) []string {
 	var values []string
-	for _, a := range p.Actions {
+	for _, a := range actions {
 		switch v := a.(type) {
 		case string:
 			values = append(values, v)
+		case float64:
+			values = append(values, actionsFromBitmask(int(v))...)
 		case map[string]interface{}:
-			if val, ok := v["value"].(string); ok {
+			switch val := v["value"].(type) {
+			case string:
 				values = append(values, val)
+			case float64:
+				values = append(values, actionsFromBitmask(int(val))...)
 			}
 		}
 	}
 	return values
 }
 
+// actionsFromBitmask returns the atomic actions set in a bitmask
+func actionsFromBitmask(mask int) []string {
+	var actions []string
+	for i, a := range Actions {
+		if mask&(1<<i) != 0 {
+			actions = append(actions, a)
+		}
+	}
+	return actions
+}
+
 // CreatePermissionRequest defines the request to create a permission
 type CreatePermissionRequest struct {
 	Name        string   `json:"name"`
@@ -83,18 +202,30 @@ type CreatePermissionRequest struct {
 	UserGroups  []string `json:"user_groups,omitempty"`
 	Assets      []string `json:"assets,omitempty"`
 	AssetGroups []string `json:"asset_groups,omitempty"`
+	Nodes       []string `json:"nodes,omitempty"`
+	Accounts    []string `json:"accounts,omitempty"`
+	Protocols   []string `json:"protocols,omitempty"`
 	Actions     []string `json:"actions"`
+	IsActive    bool     `json:"is_active"`
+	DateStart   string   `json:"date_start,omitempty"`
+	DateExpired string   `json:"date_expired,omitempty"`
 	Comment     string   `json:"comment,omitempty"`
 }
 
 // UpdatePermissionRequest defines the request to update a permission
 type UpdatePermissionRequest struct {
 	Name        string   `json:"name,omitempty"`
-	Users       []string `json:"users,omitempty"`
-	UserGroups  []string `json:"user_groups,omitempty"`
-	Assets      []string `json:"assets,omitempty"`
-	AssetGroups []string `json:"asset_groups,omitempty"`
+	Users       []string `json:"users"` // Grants are always sent so removing the last one clears them
+	UserGroups  []string `json:"user_groups"`
+	Assets      []string `json:"assets"`
+	AssetGroups []string `json:"asset_groups"`
+	Nodes       []string `json:"nodes"`
+	Accounts    []string `json:"accounts,omitempty"`
+	Protocols   []string `json:"protocols,omitempty"`
 	Actions     []string `json:"actions,omitempty"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+	DateStart   string   `json:"date_start,omitempty"`
+	DateExpired string   `json:"date_expired,omitempty"`
 	Comment     string   `json:"comment,omitempty"`
 }
 
@@ -113,17 +244,17 @@ type PermissionCreateResponse struct {
 }
 
 // CreatePermission creates a new permission
-func (c *Client) CreatePermission(req *CreatePermissionRequest) (*Permission, error) {
+func (c *Client) CreatePermission(ctx context.Context, req *CreatePermissionRequest) (*Permission, error) {
 	// Try direct Permission response first
 	var directResult Permission
-	err := c.Post("/api/v1/perms/asset-permissions/", req, &directResult)
+	err := c.Post(ctx, "/api/v1/perms/asset-permissions/", req, &directResult)
 	if err == nil && directResult.ID != "" {
 		return &directResult, nil
 	}
 
 	// Try wrapped response
 	var wrappedResult PermissionCreateResponse
-	err = c.Post("/api/v1/perms/asset-permissions/", req, &wrappedResult)
+	err = c.Post(ctx, "/api/v1/perms/asset-permissions/", req, &wrappedResult)
 	if err != nil {
 		return nil, err
 	}
@@ -135,27 +266,25 @@ func (c *Client) CreatePermission(req *CreatePermissionRequest) (*Permission, er
 }
 
 // GetPermission retrieves a permission by ID
-func (c *Client) GetPermission(id string) (*Permission, error) {
+func (c *Client) GetPermission(ctx context.Context, id string) (*Permission, error) {
 	var result Permission
-	err := c.Get(fmt.Sprintf("/api/v1/perms/asset-permissions/%s/", id), &result)
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/perms/asset-permissions/%s/", id), &result)
 	return &result, err
 }
 
-// ListPermissions retrieves a list of permissions
-func (c *Client) ListPermissions() ([]Permission, error) {
-	var result PermissionListResponse
-	err := c.Get("/api/v1/perms/asset-permissions/", &result)
-	return result.Results, err
+// ListPermissions retrieves all permissions across every page
+func (c *Client) ListPermissions(ctx context.Context) ([]Permission, error) {
+	return listAll[Permission](ctx, c, "/api/v1/perms/asset-permissions/")
 }
 
 // UpdatePermission updates an existing permission
-func (c *Client) UpdatePermission(id string, req *UpdatePermissionRequest) (*Permission, error) {
+func (c *Client) UpdatePermission(ctx context.Context, id string, req *UpdatePermissionRequest) (*Permission, error) {
 	var result Permission
-	err := c.Put(fmt.Sprintf("/api/v1/perms/asset-permissions/%s/", id), req, &result)
+	err := c.Put(ctx, fmt.Sprintf("/api/v1/perms/asset-permissions/%s/", id), req, &result)
 	return &result, err
 }
 
 // DeletePermission deletes a permission
-func (c *Client) DeletePermission(id string) error {
-	return c.Delete(fmt.Sprintf("/api/v1/perms/asset-permissions/%s/", id), nil)
+func (c *Client) DeletePermission(ctx context.Context, id string) error {
+	return c.Delete(ctx, fmt.Sprintf("/api/v1/perms/asset-permissions/%s/", id), nil)
 }
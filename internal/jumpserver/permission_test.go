@@ -0,0 +1,57 @@
+package jumpserver
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandActions(t *testing.T) {
+	tests := []struct {
+		names       []string
+		wantActions []string
+		wantUnknown []string
+	}{
+		{[]string{"all"}, Actions, nil},
+		{[]string{"connect", "transfer"}, []string{"connect", "upload", "download", "delete"}, nil},
+		{[]string{"clipboard", "paste", "share"}, []string{"copy", "paste", "share"}, nil},
+		{[]string{"delete_file", "clipboard_copy", "updownload"}, []string{"upload", "download", "copy", "delete"}, nil},
+		{[]string{"connect", "teleport"}, []string{"connect"}, []string{"teleport"}},
+	}
+
+	for _, tt := range tests {
+		actions, unknown := ExpandActions(tt.names)
+		if !reflect.DeepEqual(actions, tt.wantActions) || !reflect.DeepEqual(unknown, tt.wantUnknown) {
+			t.Errorf("ExpandActions(%v) = %v, %v; expected %v, %v", tt.names, actions, unknown, tt.wantActions, tt.wantUnknown)
+		}
+	}
+}
+
+func TestGetActionValues(t *testing.T) {
+	p := Permission{Actions: []interface{}{
+		"connect",
+		map[string]interface{}{"value": "copy", "label": "Copy"},
+		map[string]interface{}{"value": float64(6), "label": "Transfer"},
+		float64(64),
+	}}
+
+	want := []string{"connect", "copy", "upload", "download", "share"}
+	if got := p.GetActionValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestUpdatePermissionRequestSendsEmptyGrants(t *testing.T) {
+	data, err := json.Marshal(UpdatePermissionRequest{
+		Users: []string{}, UserGroups: []string{}, Assets: []string{}, AssetGroups: []string{}, Nodes: []string{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"users", "user_groups", "assets", "asset_groups", "nodes"} {
+		if !strings.Contains(string(data), `"`+field+`":[]`) {
+			t.Errorf("expected %s to be sent as an empty list, got %s", field, data)
+		}
+	}
+}
@@ -1,7 +1,7 @@
 package jumpserver
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 )
 
@@ -13,32 +13,108 @@ type PlatformListResponse struct {
 	Results  []Platform `json:"results"`
 }
 
-// ListPlatforms retrieves a list of platforms
-func (c *Client) ListPlatforms() ([]Platform, error) {
-	// Try to unmarshal as paginated response first
-	var paginated PlatformListResponse
-	resp, err := c.getRaw("/api/v1/assets/platforms/")
-	if err != nil {
-		return nil, err
-	}
+// PlatformProtocol represents a protocol offered by a platform, with the defaults applied to its assets
+type PlatformProtocol struct {
+	Name     string           `json:"name"`
+	Port     int              `json:"port"`
+	Primary  bool             `json:"primary"`
+	Required bool             `json:"required"`
+	Default  bool             `json:"default"`
+	Public   bool             `json:"public"`
+	Setting  *ProtocolSetting `json:"setting,omitempty"`
+}
 
-	// Try paginated format first
-	if err := json.Unmarshal(resp, &paginated); err == nil {
-		return paginated.Results, nil
-	}
+// PlatformAutomation holds the automation toggles and methods of a platform
+type PlatformAutomation struct {
+	AnsibleEnabled        *bool  `json:"ansible_enabled,omitempty"`
+	PingEnabled           *bool  `json:"ping_enabled,omitempty"`
+	PingMethod            string `json:"ping_method,omitempty"`
+	GatherFactsEnabled    *bool  `json:"gather_facts_enabled,omitempty"`
+	GatherFactsMethod     string `json:"gather_facts_method,omitempty"`
+	PushAccountEnabled    *bool  `json:"push_account_enabled,omitempty"`
+	PushAccountMethod     string `json:"push_account_method,omitempty"`
+	ChangeSecretEnabled   *bool  `json:"change_secret_enabled,omitempty"`
+	ChangeSecretMethod    string `json:"change_secret_method,omitempty"`
+	VerifyAccountEnabled  *bool  `json:"verify_account_enabled,omitempty"`
+	VerifyAccountMethod   string `json:"verify_account_method,omitempty"`
+	GatherAccountsEnabled *bool  `json:"gather_accounts_enabled,omitempty"`
+	GatherAccountsMethod  string `json:"gather_accounts_method,omitempty"`
+}
+
+// CreatePlatformRequest defines the request to create a platform
+type CreatePlatformRequest struct {
+	Name          string              `json:"name"`
+	Type          string              `json:"type"`
+	Category      string              `json:"category"`
+	Charset       string              `json:"charset,omitempty"`
+	DomainEnabled *bool               `json:"domain_enabled,omitempty"`
+	SuEnabled     *bool               `json:"su_enabled,omitempty"`
+	SuMethod      string              `json:"su_method,omitempty"`
+	Protocols     []PlatformProtocol  `json:"protocols,omitempty"`
+	Automation    *PlatformAutomation `json:"automation,omitempty"`
+	Comment       string              `json:"comment,omitempty"`
+}
+
+// UpdatePlatformRequest defines the request to update a platform
+type UpdatePlatformRequest struct {
+	Name          string              `json:"name"`
+	Type          string              `json:"type"`
+	Category      string              `json:"category"`
+	Charset       string              `json:"charset,omitempty"`
+	DomainEnabled *bool               `json:"domain_enabled,omitempty"`
+	SuEnabled     *bool               `json:"su_enabled,omitempty"`
+	SuMethod      string              `json:"su_method,omitempty"`
+	Protocols     []PlatformProtocol  `json:"protocols,omitempty"`
+	Automation    *PlatformAutomation `json:"automation,omitempty"`
+	Comment       string              `json:"comment"`
+}
+
+// GetCharsetValue returns the charset as string
+func (p *Platform) GetCharsetValue() string {
+	return choiceValue(p.Charset)
+}
 
-	// If that fails, try direct array format
-	var platforms []Platform
-	if err := json.Unmarshal(resp, &platforms); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response as paginated or array: %w", err)
+// GetSuMethodValue returns the su method as string
+func (p *Platform) GetSuMethodValue() string {
+	return choiceValue(p.SuMethod)
+}
+
+// choiceValue returns the value of a choice field, which the API renders either as
+// a plain string or as an object {"value":"", "label":""}
+func choiceValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if val, ok := v["value"].(string); ok {
+			return val
+		}
 	}
+	return ""
+}
+
+// CreatePlatform creates a new platform
+func (c *Client) CreatePlatform(ctx context.Context, req *CreatePlatformRequest) (*Platform, error) {
+	var result Platform
+	err := c.Post(ctx, "/api/v1/assets/platforms/", req, &result)
+	return &result, err
+}
 
-	return platforms, nil
+// GetPlatform retrieves a platform by ID
+func (c *Client) GetPlatform(ctx context.Context, id string) (*Platform, error) {
+	var result Platform
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/assets/platforms/%s/", id), &result)
+	return &result, err
+}
+
+// ListPlatforms retrieves all platforms across every page
+func (c *Client) ListPlatforms(ctx context.Context) ([]Platform, error) {
+	return listAll[Platform](ctx, c, "/api/v1/assets/platforms/")
 }
 
 // GetPlatformByName retrieves a platform by name
-func (c *Client) GetPlatformByName(name string) (*Platform, error) {
-	platforms, err := c.ListPlatforms()
+func (c *Client) GetPlatformByName(ctx context.Context, name string) (*Platform, error) {
+	platforms, err := c.ListPlatforms(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -49,5 +125,17 @@ func (c *Client) GetPlatformByName(name string) (*Platform, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("platform not found: %s", name)
+	return nil, &NotFoundError{Kind: "platform", Name: name}
+}
+
+// UpdatePlatform updates an existing platform
+func (c *Client) UpdatePlatform(ctx context.Context, id string, req *UpdatePlatformRequest) (*Platform, error) {
+	var result Platform
+	err := c.Put(ctx, fmt.Sprintf("/api/v1/assets/platforms/%s/", id), req, &result)
+	return &result, err
+}
+
+// DeletePlatform deletes a platform
+func (c *Client) DeletePlatform(ctx context.Context, id string) error {
+	return c.Delete(ctx, fmt.Sprintf("/api/v1/assets/platforms/%s/", id), nil)
 }
@@ -0,0 +1,127 @@
+package jumpserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries the provider configures when unset
+	DefaultMaxRetries = 3
+
+	// DefaultRetryMinBackoff is the wait before the first retry
+	DefaultRetryMinBackoff = 1 * time.Second
+
+	// DefaultRetryMaxBackoff caps the exponential backoff between retries
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+type retryNonIdempotentKey struct{}
+
+// WithRetryNonIdempotent returns a context that allows retrying non-idempotent
+// requests such as POST. Use it only when repeating the request is known to be safe.
+func WithRetryNonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryNonIdempotentKey{}, true)
+}
+
+// retryNonIdempotent reports whether the caller opted in to retrying non-idempotent requests
+func retryNonIdempotent(ctx context.Context) bool {
+	allowed, _ := ctx.Value(retryNonIdempotentKey{}).(bool)
+	return allowed
+}
+
+// isIdempotent reports whether repeating a request with this method is safe
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry reports whether a failed attempt is transient and worth repeating
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return true
+		}
+
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryBackoff returns how long to wait before the next attempt. A Retry-After
+// header sent by the server takes precedence over the exponential backoff, but is
+// still capped at RetryMaxBackoff.
+func (c *Client) retryBackoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, c.config.RetryMaxBackoff)
+		}
+	}
+
+	wait := c.config.RetryMaxBackoff
+	if shift := attempt - 1; shift < 32 {
+		if backoff := c.config.RetryMinBackoff << shift; backoff > 0 && backoff < wait {
+			wait = backoff
+		}
+	}
+
+	// Add up to 25% jitter so concurrent applies don't retry in lockstep
+	if jitter := int64(wait / 4); jitter > 0 {
+		wait += time.Duration(rand.Int64N(jitter))
+	}
+
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header in either delay-seconds or HTTP-date form
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
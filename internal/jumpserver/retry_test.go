@@ -0,0 +1,80 @@
+package jumpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDoRequestRetriesTransientFailures(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id":"u1","username":"alice"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL, MaxRetries: 3, RetryMinBackoff: time.Millisecond})
+
+	user, err := client.GetUser(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("GetUser returned error: %s", err)
+	}
+	if user.Username != "alice" || attempts != 3 {
+		t.Errorf("expected alice after 3 attempts, got %q after %d", user.Username, attempts)
+	}
+}
+
+func TestDoRequestDoesNotRetryPost(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL, MaxRetries: 3, RetryMinBackoff: time.Millisecond})
+
+	if _, err := client.CreateUser(context.Background(), &CreateUserRequest{Username: "alice"}); err == nil {
+		t.Fatal("expected CreateUser to fail")
+	}
+	if attempts != 1 {
+		t.Errorf("expected a single POST attempt, got %d", attempts)
+	}
+
+	attempts = 0
+	ctx := WithRetryNonIdempotent(context.Background())
+	if _, err := client.CreateUser(ctx, &CreateUserRequest{Username: "alice"}); err == nil {
+		t.Fatal("expected CreateUser to fail")
+	}
+	if attempts != 4 {
+		t.Errorf("expected 4 POST attempts after opting in, got %d", attempts)
+	}
+}
+
+func TestRetryBackoffCapsRetryAfter(t *testing.T) {
+	client := NewClient(&Config{RetryMinBackoff: time.Second, RetryMaxBackoff: 5 * time.Second})
+
+	tests := []struct {
+		retryAfter string
+		want       time.Duration
+	}{
+		{"2", 2 * time.Second},
+		{"3600", 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{tt.retryAfter}}}
+		if got := client.retryBackoff(1, resp); got != tt.want {
+			t.Errorf("Retry-After %s: expected %s, got %s", tt.retryAfter, tt.want, got)
+		}
+	}
+}
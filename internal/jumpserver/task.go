@@ -1,6 +1,7 @@
 package jumpserver
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -34,14 +35,14 @@ type CommandExecutionResponse struct {
 	Mark string `json:"mark"`
 }
 
-func (c *Client) CreateExecuteCommand(req *CreateCommandExecutionRequest) (*CommandExecutionResponse, error) {
+func (c *Client) CreateExecuteCommand(ctx context.Context, req *CreateCommandExecutionRequest) (*CommandExecutionResponse, error) {
 	var result CommandExecutionResponse
-	err := c.Post("/api/v1/ops/jobs/", req, &result)
+	err := c.Post(ctx, "/api/v1/ops/jobs/", req, &result)
 	return &result, err
 }
 
-func (c *Client) GetCommandExecution(id string) (*CommandExecutionResponse, error) {
+func (c *Client) GetCommandExecution(ctx context.Context, id string) (*CommandExecutionResponse, error) {
 	var result CommandExecutionResponse
-	err := c.Get(fmt.Sprintf("/api/v1/ops/celery/task/00000000-0000-0000-0000-000000000002/task-execution/%s/log/", id), &result)
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/ops/celery/task/00000000-0000-0000-0000-000000000002/task-execution/%s/log/", id), &result)
 	return &result, err
 }
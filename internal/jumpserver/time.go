@@ -0,0 +1,25 @@
+package jumpserver
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeLayouts are the date formats JumpServer renders depending on version and settings
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006/01/02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -0700",
+	"2006/01/02 15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// ParseTime parses a date returned by the API. Dates without a zone are taken as UTC.
+func ParseTime(value string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", value)
+}
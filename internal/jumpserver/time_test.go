@@ -0,0 +1,33 @@
+package jumpserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2026, 3, 31, 16, 0, 0, 0, time.UTC)
+
+	tests := []string{
+		"2026-03-31T16:00:00Z",
+		"2026-04-01T00:00:00+08:00",
+		"2026-04-01T00:00:00.000000+08:00",
+		"2026/04/01 00:00:00 +0800",
+		"2026-04-01 00:00:00 +0800",
+		"2026/03/31 16:00:00",
+	}
+	for _, value := range tests {
+		got, err := ParseTime(value)
+		if err != nil {
+			t.Errorf("ParseTime(%q) returned error: %s", value, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseTime(%q) = %s, expected %s", value, got, want)
+		}
+	}
+
+	if _, err := ParseTime("next tuesday"); err == nil {
+		t.Error("expected an error for an unrecognized date")
+	}
+}
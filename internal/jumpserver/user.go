@@ -1,35 +1,122 @@
 package jumpserver
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
+
+// Password strategies used when a user is created
+const (
+	PasswordStrategyEmail  = "email"
+	PasswordStrategyCustom = "custom"
+)
 
 // User represents a JumpServer user
 type User struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Comment  string `json:"comment,omitempty"`
-	IsActive bool   `json:"is_active"`
-	Created  string `json:"date_created,omitempty"`
-	Updated  string `json:"date_updated,omitempty"`
+	ID                 string        `json:"id"`
+	Username           string        `json:"username"`
+	Name               string        `json:"name"`
+	Email              string        `json:"email"`
+	Comment            string        `json:"comment,omitempty"`
+	IsActive           bool          `json:"is_active"`
+	Groups             []interface{} `json:"groups,omitempty"`       // Can be IDs or objects {"id":"", "name":""}
+	SystemRoles        []interface{} `json:"system_roles,omitempty"` // Can be IDs or objects {"id":"", "name":"", "display_name":""}
+	OrgRoles           []interface{} `json:"org_roles,omitempty"`    // Can be IDs or objects {"id":"", "name":"", "display_name":""}
+	MFALevel           interface{}   `json:"mfa_level,omitempty"`    // Can be a number or object {"value":0, "label":""}
+	Source             interface{}   `json:"source,omitempty"`       // Can be string or object {"value":"", "label":""}
+	DateExpired        string        `json:"date_expired,omitempty"`
+	Phone              interface{}   `json:"phone,omitempty"` // Can be string or object {"code":"", "phone":""}
+	Wechat             string        `json:"wechat,omitempty"`
+	NeedUpdatePassword bool          `json:"need_update_password"`
+	Created            string        `json:"date_created,omitempty"`
+	Updated            string        `json:"date_updated,omitempty"`
 }
 
 // CreateUserRequest defines the request to create a user
 type CreateUserRequest struct {
-	Username string `json:"username"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Comment  string `json:"comment,omitempty"`
-	IsActive bool   `json:"is_active"`
+	Username           string   `json:"username"`
+	Name               string   `json:"name"`
+	Email              string   `json:"email"`
+	Comment            string   `json:"comment,omitempty"`
+	IsActive           bool     `json:"is_active"`
+	Groups             []string `json:"groups,omitempty"`
+	SystemRoles        []string `json:"system_roles,omitempty"`
+	OrgRoles           []string `json:"org_roles,omitempty"`
+	MFALevel           *int     `json:"mfa_level,omitempty"`
+	Source             string   `json:"source,omitempty"`
+	DateExpired        string   `json:"date_expired,omitempty"`
+	Phone              string   `json:"phone,omitempty"`
+	Wechat             string   `json:"wechat,omitempty"`
+	NeedUpdatePassword *bool    `json:"need_update_password,omitempty"`
+	PasswordStrategy   string   `json:"password_strategy,omitempty"`
+	Password           string   `json:"password,omitempty"`
 }
 
 // UpdateUserRequest defines the request to update a user
 type UpdateUserRequest struct {
-	Username string `json:"username,omitempty"`
-	Name     string `json:"name,omitempty"`
-	Email    string `json:"email,omitempty"`
-	Comment  string `json:"comment,omitempty"`
-	IsActive *bool  `json:"is_active,omitempty"`
+	Username           string    `json:"username,omitempty"`
+	Name               string    `json:"name,omitempty"`
+	Email              string    `json:"email,omitempty"`
+	Comment            string    `json:"comment,omitempty"`
+	IsActive           *bool     `json:"is_active,omitempty"`
+	Groups             *[]string `json:"groups,omitempty"`       // nil leaves the groups untouched, an empty list clears them
+	SystemRoles        *[]string `json:"system_roles,omitempty"` // nil leaves the roles untouched, an empty list clears them
+	OrgRoles           *[]string `json:"org_roles,omitempty"`
+	MFALevel           *int      `json:"mfa_level,omitempty"`
+	Source             *string   `json:"source,omitempty"`
+	DateExpired        *string   `json:"date_expired,omitempty"`
+	Phone              *string   `json:"phone,omitempty"`
+	Wechat             *string   `json:"wechat,omitempty"`
+	NeedUpdatePassword *bool     `json:"need_update_password,omitempty"`
+}
+
+// GetGroupIDs returns the IDs of the groups the user belongs to
+func (u *User) GetGroupIDs() []string {
+	return objectIDs(u.Groups)
+}
+
+// GetSystemRoleIDs returns the IDs of the system roles granted to the user
+func (u *User) GetSystemRoleIDs() []string {
+	return objectIDs(u.SystemRoles)
+}
+
+// GetOrgRoleIDs returns the IDs of the roles granted to the user in the current organization
+func (u *User) GetOrgRoleIDs() []string {
+	return objectIDs(u.OrgRoles)
+}
+
+// GetMFALevelValue returns the MFA level: 0 disabled, 1 enabled, 2 forced
+func (u *User) GetMFALevelValue() int {
+	switch v := u.MFALevel.(type) {
+	case float64:
+		return int(v)
+	case map[string]interface{}:
+		if val, ok := v["value"].(float64); ok {
+			return int(val)
+		}
+	}
+	return 0
+}
+
+// GetSourceValue returns the source of the user as a string
+func (u *User) GetSourceValue() string {
+	return choiceValue(u.Source)
+}
+
+// GetPhoneValue returns the phone number of the user, prefixed with its country code when the API reports one
+func (u *User) GetPhoneValue() string {
+	switch v := u.Phone.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		phone, _ := v["phone"].(string)
+		code, _ := v["code"].(string)
+		if phone == "" {
+			return ""
+		}
+		return code + phone
+	}
+	return ""
 }
 
 // UserListResponse represents a paginated list of users
@@ -41,22 +128,22 @@ type UserListResponse struct {
 }
 
 // CreateUser creates a new user
-func (c *Client) CreateUser(req *CreateUserRequest) (*User, error) {
+func (c *Client) CreateUser(ctx context.Context, req *CreateUserRequest) (*User, error) {
 	var result User
-	err := c.Post("/api/v1/users/users/", req, &result)
+	err := c.Post(ctx, "/api/v1/users/users/", req, &result)
 	return &result, err
 }
 
 // GetUser retrieves a user by ID
-func (c *Client) GetUser(id string) (*User, error) {
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
 	var result User
-	err := c.Get(fmt.Sprintf("/api/v1/users/users/%s/", id), &result)
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/users/users/%s/", id), &result)
 	return &result, err
 }
 
 // GetUserByUsername retrieves a user by username
-func (c *Client) GetUserByUsername(username string) (*User, error) {
-	users, err := c.ListUsers()
+func (c *Client) GetUserByUsername(ctx context.Context, username string) (*User, error) {
+	users, err := c.ListUsers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -67,24 +154,22 @@ func (c *Client) GetUserByUsername(username string) (*User, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("user not found: %s", username)
+	return nil, &NotFoundError{Kind: "user", Name: username}
 }
 
-// ListUsers retrieves a list of users
-func (c *Client) ListUsers() ([]User, error) {
-	var result UserListResponse
-	err := c.Get("/api/v1/users/users/", &result)
-	return result.Results, err
+// ListUsers retrieves all users across every page
+func (c *Client) ListUsers(ctx context.Context) ([]User, error) {
+	return listAll[User](ctx, c, "/api/v1/users/users/")
 }
 
 // UpdateUser updates an existing user
-func (c *Client) UpdateUser(id string, req *UpdateUserRequest) (*User, error) {
+func (c *Client) UpdateUser(ctx context.Context, id string, req *UpdateUserRequest) (*User, error) {
 	var result User
-	err := c.Put(fmt.Sprintf("/api/v1/users/users/%s/", id), req, &result)
+	err := c.Put(ctx, fmt.Sprintf("/api/v1/users/users/%s/", id), req, &result)
 	return &result, err
 }
 
 // DeleteUser deletes a user
-func (c *Client) DeleteUser(id string) error {
-	return c.Delete(fmt.Sprintf("/api/v1/users/users/%s/", id), nil)
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	return c.Delete(ctx, fmt.Sprintf("/api/v1/users/users/%s/", id), nil)
 }
@@ -0,0 +1,40 @@
+package jumpserver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetUserGroupMembersSendsEmptyList(t *testing.T) {
+	var method, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, body = r.Method, string(data)
+		w.Write([]byte(`{"id":"g1","name":"ops","users":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL})
+
+	if _, err := client.SetUserGroupMembers(context.Background(), "g1", nil); err != nil {
+		t.Fatalf("SetUserGroupMembers returned error: %s", err)
+	}
+	if method != http.MethodPatch || body != `{"users":[]}` {
+		t.Errorf("expected PATCH with an empty user list, got %s %s", method, body)
+	}
+}
+
+func TestUserGroupGetUserIDs(t *testing.T) {
+	group := UserGroup{Users: []interface{}{
+		"u1",
+		map[string]interface{}{"id": "u2", "name": "Jane", "username": "jane"},
+	}}
+
+	ids := group.GetUserIDs()
+	if len(ids) != 2 || ids[0] != "u1" || ids[1] != "u2" {
+		t.Errorf("expected [u1 u2], got %v", ids)
+	}
+}
@@ -0,0 +1,69 @@
+package jumpserver
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// PermedAsset is an asset a user is granted access to, with the accounts and protocols
+// the combined permissions allow on it
+type PermedAsset struct {
+	Asset
+	PermedAccounts  []PermedAccount `json:"permed_accounts"`
+	PermedProtocols []Protocol      `json:"permed_protocols"`
+}
+
+// PermedAccount is an account a user may log in with on an asset
+type PermedAccount struct {
+	ID         string        `json:"id"`
+	Alias      string        `json:"alias"`
+	Name       string        `json:"name"`
+	Username   string        `json:"username"`
+	HasSecret  bool          `json:"has_secret"`
+	SecretType interface{}   `json:"secret_type"` // Can be string or object {"value":"", "label":""}
+	Actions    []interface{} `json:"actions"`     // Can be string array, object array or bitmask
+}
+
+// GetActionValues extracts the actions allowed with the account
+func (a *PermedAccount) GetActionValues() []string {
+	return actionValues(a.Actions)
+}
+
+// GetSecretTypeValue extracts the secret type value
+func (a *PermedAccount) GetSecretTypeValue() string {
+	return choiceValue(a.SecretType)
+}
+
+// PermissionValidation is the result of checking a single access against the permissions
+type PermissionValidation struct {
+	HasPerm  bool  `json:"has_perm"`
+	ExpireAt int64 `json:"expire_at"` // Unix time the access ends, when granted
+}
+
+// ListUserPermedAssets retrieves every asset the user is granted access to
+func (c *Client) ListUserPermedAssets(ctx context.Context, userID string) ([]Asset, error) {
+	return listAll[Asset](ctx, c, fmt.Sprintf("/api/v1/perms/users/%s/assets/", userID))
+}
+
+// GetUserPermedAsset retrieves an asset the user is granted access to, together with
+// the accounts and protocols the user may use on it
+func (c *Client) GetUserPermedAsset(ctx context.Context, userID, assetID string) (*PermedAsset, error) {
+	var result PermedAsset
+	err := c.Get(ctx, fmt.Sprintf("/api/v1/perms/users/%s/assets/%s/", userID, assetID), &result)
+	return &result, err
+}
+
+// ValidateUserAssetPermission checks whether the user may perform the action on the
+// asset with the given account
+func (c *Client) ValidateUserAssetPermission(ctx context.Context, userID, assetID, account, action string) (*PermissionValidation, error) {
+	query := url.Values{}
+	query.Set("user_id", userID)
+	query.Set("asset_id", assetID)
+	query.Set("account", account)
+	query.Set("action_name", action)
+
+	var result PermissionValidation
+	err := c.Get(ctx, "/api/v1/perms/asset-permissions/user/validate/?"+query.Encode(), &result)
+	return &result, err
+}
@@ -0,0 +1,55 @@
+package jumpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateUserAssetPermission(t *testing.T) {
+	var path, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.RawQuery
+		w.Write([]byte(`{"has_perm":true,"expire_at":4102444800}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL})
+
+	result, err := client.ValidateUserAssetPermission(context.Background(), "u1", "a1", "root", ActionUpload)
+	if err != nil {
+		t.Fatalf("ValidateUserAssetPermission returned error: %s", err)
+	}
+	if path != "/api/v1/perms/asset-permissions/user/validate/" || query != "account=root&action_name=upload&asset_id=a1&user_id=u1" {
+		t.Errorf("unexpected request %s?%s", path, query)
+	}
+	if !result.HasPerm || result.ExpireAt != 4102444800 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestGetUserPermedAsset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/perms/users/u1/assets/a1/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"a1","name":"web","address":"10.0.0.1",
+			"permed_protocols":[{"name":"ssh","port":22}],
+			"permed_accounts":[{"name":"root","username":"root","alias":"acc1","actions":[{"value":"connect","label":"Connect"},{"value":"copy","label":"Copy"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{Endpoint: server.URL})
+
+	asset, err := client.GetUserPermedAsset(context.Background(), "u1", "a1")
+	if err != nil {
+		t.Fatalf("GetUserPermedAsset returned error: %s", err)
+	}
+	if asset.ID != "a1" || len(asset.PermedProtocols) != 1 || len(asset.PermedAccounts) != 1 {
+		t.Fatalf("unexpected asset %+v", asset)
+	}
+	if actions := asset.PermedAccounts[0].GetActionValues(); len(actions) != 2 || actions[1] != ActionCopy {
+		t.Errorf("expected connect and copy, got %v", actions)
+	}
+}
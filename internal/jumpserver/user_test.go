@@ -0,0 +1,61 @@
+package jumpserver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUserLifecycleFields(t *testing.T) {
+	var user User
+	data := `{
+		"id": "u1",
+		"system_roles": [{"id": "00000000-0000-0000-0000-000000000003", "display_name": "User"}],
+		"org_roles": ["00000000-0000-0000-0000-000000000007"],
+		"mfa_level": {"value": 2, "label": "Force enable"},
+		"source": {"value": "ldap", "label": "LDAP"},
+		"phone": {"code": "+86", "phone": "13800000000"}
+	}`
+	if err := json.Unmarshal([]byte(data), &user); err != nil {
+		t.Fatalf("failed to decode user: %s", err)
+	}
+
+	if roles := user.GetSystemRoleIDs(); len(roles) != 1 || roles[0] != "00000000-0000-0000-0000-000000000003" {
+		t.Errorf("unexpected system roles %v", roles)
+	}
+	if roles := user.GetOrgRoleIDs(); len(roles) != 1 || roles[0] != OrgUserRoleID {
+		t.Errorf("unexpected org roles %v", roles)
+	}
+	if level := user.GetMFALevelValue(); level != 2 {
+		t.Errorf("expected MFA level 2, got %d", level)
+	}
+	if source := user.GetSourceValue(); source != "ldap" {
+		t.Errorf("expected source ldap, got %q", source)
+	}
+	if phone := user.GetPhoneValue(); phone != "+8613800000000" {
+		t.Errorf("expected phone +8613800000000, got %q", phone)
+	}
+
+	user.MFALevel = float64(1)
+	user.Phone = "+15550100"
+	if user.GetMFALevelValue() != 1 || user.GetPhoneValue() != "+15550100" {
+		t.Errorf("expected plain MFA level and phone values to be returned as is")
+	}
+}
+
+func TestUpdateUserRequestSendsEmptyRoles(t *testing.T) {
+	data, err := json.Marshal(UpdateUserRequest{SystemRoles: &[]string{}, OrgRoles: &[]string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != `{"system_roles":[],"org_roles":[]}` {
+		t.Errorf("expected empty role lists to be sent, got %s", got)
+	}
+
+	data, err = json.Marshal(UpdateUserRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != `{}` {
+		t.Errorf("expected unset fields to be left out, got %s", got)
+	}
+}
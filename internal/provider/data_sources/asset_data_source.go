@@ -30,10 +30,12 @@ type AssetDataSourceModel struct {
 	Name     types.String `tfsdk:"name"`
 	Address  types.String `tfsdk:"address"`
 	Platform types.String `tfsdk:"platform"`
+	Category types.String `tfsdk:"category"`
 	Gateway  types.String `tfsdk:"gateway"`
 	Nodes    types.List   `tfsdk:"nodes"`
 	IsActive types.Bool   `tfsdk:"is_active"`
 	Comment  types.String `tfsdk:"comment"`
+	OrgID    types.String `tfsdk:"org_id"`
 }
 
 func (d *AssetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -60,6 +62,10 @@ func (d *AssetDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:    true,
 				Description: "The platform name",
 			},
+			"category": schema.StringAttribute{
+				Computed:    true,
+				Description: "The asset category (host, database, web, device, cloud or custom)",
+			},
 			"gateway": schema.StringAttribute{
 				Computed:    true,
 				Description: "The gateway asset ID",
@@ -77,6 +83,7 @@ func (d *AssetDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:    true,
 				Description: "Additional comments",
 			},
+			"org_id": orgIDAttribute(),
 		},
 	}
 }
@@ -106,7 +113,9 @@ func (d *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	asset, err := d.client.GetAsset(config.ID.ValueString())
+	ctx = jumpserver.WithOrgID(ctx, config.OrgID.ValueString())
+
+	asset, err := d.client.GetAsset(ctx, "", config.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading asset",
@@ -119,12 +128,13 @@ func (d *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	config.Name = types.StringValue(asset.Name)
 	config.Address = types.StringValue(asset.Address)
 	config.Platform = types.StringValue(asset.Platform.Name)
+	config.Category = types.StringValue(asset.GetCategoryValue())
 
-	var nodeIDs []any
+	var nodeIDs []string
 	for _, node := range asset.Nodes {
 		nodeIDs = append(nodeIDs, node.ID)
 	}
-	diags = config.Nodes.ElementsAs(ctx, nodeIDs, false)
+	config.Nodes, diags = types.ListValueFrom(ctx, types.StringType, nodeIDs)
 	resp.Diagnostics.Append(diags...)
 
 	config.IsActive = types.BoolValue(asset.IsActive)
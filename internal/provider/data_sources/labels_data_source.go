@@ -0,0 +1,232 @@
+package data_sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ datasource.DataSource              = &LabelsDataSource{}
+	_ datasource.DataSourceWithConfigure = &LabelsDataSource{}
+)
+
+func NewLabelsDataSource() datasource.DataSource {
+	return &LabelsDataSource{}
+}
+
+type LabelsDataSource struct {
+	client *jumpserver.Client
+}
+
+type LabelsDataSourceModel struct {
+	Name   types.String `tfsdk:"name"`
+	Keys   types.Set    `tfsdk:"keys"`
+	Labels types.List   `tfsdk:"labels"`
+	IDs    types.Map    `tfsdk:"ids"`
+	OrgID  types.String `tfsdk:"org_id"`
+}
+
+type LabelModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Value    types.String `tfsdk:"value"`
+	Key      types.String `tfsdk:"key"`
+	Color    types.String `tfsdk:"color"`
+	Comment  types.String `tfsdk:"comment"`
+	ResCount types.Int64  `tfsdk:"res_count"`
+}
+
+var labelAttrTypes = map[string]attr.Type{
+	"id":        types.StringType,
+	"name":      types.StringType,
+	"value":     types.StringType,
+	"key":       types.StringType,
+	"color":     types.StringType,
+	"comment":   types.StringType,
+	"res_count": types.Int64Type,
+}
+
+func (d *LabelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_labels"
+}
+
+func (d *LabelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves JumpServer labels, optionally filtered by name or looked up by name:value",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return labels with this name (e.g., 'env')",
+			},
+			"keys": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Labels to look up in name:value form (e.g., 'env:prod'). Every key must exist",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"labels": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching labels",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of the label",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The label name",
+						},
+						"value": schema.StringAttribute{
+							Computed:    true,
+							Description: "The label value",
+						},
+						"key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The label in name:value form",
+						},
+						"color": schema.StringAttribute{
+							Computed:    true,
+							Description: "The display color of the label",
+						},
+						"comment": schema.StringAttribute{
+							Computed:    true,
+							Description: "Additional comments about the label",
+						},
+						"res_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of resources the label is attached to",
+						},
+					},
+				},
+			},
+			"ids": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Label IDs keyed by name:value",
+			},
+			"org_id": orgIDAttribute(),
+		},
+	}
+}
+
+func (d *LabelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *LabelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config LabelsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = jumpserver.WithOrgID(ctx, config.OrgID.ValueString())
+
+	var keys []string
+	if !config.Keys.IsNull() {
+		diags = config.Keys.ElementsAs(ctx, &keys, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	for _, key := range keys {
+		if _, _, err := jumpserver.SplitLabelKey(key); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("keys"), "Invalid label key", err.Error())
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	labels, err := d.client.ListLabels(ctx, config.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading labels",
+			fmt.Sprintf("Could not list labels: %s", err),
+		)
+		return
+	}
+
+	byKey := make(map[string]jumpserver.Label, len(labels))
+	for _, l := range labels {
+		byKey[l.Key()] = l
+	}
+
+	// Without keys every label (of the given name) is returned
+	var matched []jumpserver.Label
+	if len(keys) == 0 {
+		matched = labels
+	}
+	for _, key := range keys {
+		l, ok := byKey[key]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("keys"),
+				"Label not found",
+				fmt.Sprintf("No label %s exists in JumpServer.", key),
+			)
+			continue
+		}
+		matched = append(matched, l)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	models := make([]LabelModel, 0, len(matched))
+	ids := make(map[string]string, len(matched))
+	for _, l := range matched {
+		models = append(models, LabelModel{
+			ID:       types.StringValue(l.ID),
+			Name:     types.StringValue(l.Name),
+			Value:    types.StringValue(l.Value),
+			Key:      types.StringValue(l.Key()),
+			Color:    types.StringValue(l.Color),
+			Comment:  types.StringValue(l.Comment),
+			ResCount: types.Int64Value(int64(l.ResCount)),
+		})
+		ids[l.Key()] = l.ID
+	}
+
+	config.Labels, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: labelAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	config.IDs, diags = types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read labels data source", map[string]any{"count": len(models)})
+
+	diags = resp.State.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -29,6 +29,7 @@ type NodeDataSourceModel struct {
 	ID       types.String `tfsdk:"id"`
 	FullName types.String `tfsdk:"full_name"`
 	Weight   types.Int64  `tfsdk:"weight"`
+	OrgID    types.String `tfsdk:"org_id"`
 }
 
 func (d *NodeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,6 +52,7 @@ func (d *NodeDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:    true,
 				Description: "The weight of the node",
 			},
+			"org_id": orgIDAttribute(),
 		},
 	}
 }
@@ -80,7 +82,9 @@ func (d *NodeDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	node, err := d.client.GetNodeByFullName(config.ID.ValueString())
+	ctx = jumpserver.WithOrgID(ctx, config.OrgID.ValueString())
+
+	node, err := d.client.GetNodeByFullName(ctx, config.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading node",
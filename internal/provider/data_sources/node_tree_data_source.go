@@ -0,0 +1,384 @@
+package data_sources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+// maxNodeTreeDepth is the number of child levels the schema can describe. Terraform
+// schemas cannot be recursive, so the nested children attribute is unrolled this deep.
+const maxNodeTreeDepth = 5
+
+var (
+	_ datasource.DataSource              = &NodeTreeDataSource{}
+	_ datasource.DataSourceWithConfigure = &NodeTreeDataSource{}
+)
+
+func NewNodeTreeDataSource() datasource.DataSource {
+	return &NodeTreeDataSource{}
+}
+
+type NodeTreeDataSource struct {
+	client *jumpserver.Client
+}
+
+type NodeTreeDataSourceModel struct {
+	Root             types.String `tfsdk:"root"`
+	Depth            types.Int64  `tfsdk:"depth"`
+	Recursive        types.Bool   `tfsdk:"recursive"`
+	ID               types.String `tfsdk:"id"`
+	Key              types.String `tfsdk:"key"`
+	Value            types.String `tfsdk:"value"`
+	FullValue        types.String `tfsdk:"full_value"`
+	AssetCount       types.Int64  `tfsdk:"asset_count"`
+	DirectAssetCount types.Int64  `tfsdk:"direct_asset_count"`
+	Children         types.List   `tfsdk:"children"`
+	AssetIDs         types.Set    `tfsdk:"asset_ids"`
+	OrgID            types.String `tfsdk:"org_id"`
+}
+
+func (d *NodeTreeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_tree"
+}
+
+func (d *NodeTreeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves a subtree of the JumpServer asset tree with its children, asset counts and asset IDs",
+		Attributes: map[string]schema.Attribute{
+			"root": schema.StringAttribute{
+				Required:    true,
+				Description: "ID or full path (e.g., '/Default/Prod') of the node at the root of the subtree",
+			},
+			"depth": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("How many levels of children to return, from 0 to %d. Defaults to 1", maxNodeTreeDepth),
+				Validators: []validator.Int64{
+					int64validator.Between(0, maxNodeTreeDepth),
+				},
+			},
+			"recursive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether asset_ids includes the assets of every descendant node instead of only those attached to the root node. Defaults to false",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the root node",
+			},
+			"key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The position of the root node in the tree (e.g., '1:3')",
+			},
+			"value": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the root node",
+			},
+			"full_value": schema.StringAttribute{
+				Computed:    true,
+				Description: "The full path of the root node",
+			},
+			"asset_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of assets in the whole subtree",
+			},
+			"direct_asset_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of assets attached directly to the root node",
+			},
+			"children": nodeTreeChildrenAttribute(1),
+			"asset_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the assets attached to the root node, or to any node of the subtree when recursive is set",
+			},
+			"org_id": orgIDAttribute(),
+		},
+	}
+}
+
+// nodeTreeChildrenAttribute builds the children attribute for the given level, nesting
+// further children until maxNodeTreeDepth is reached
+func nodeTreeChildrenAttribute(level int) schema.ListNestedAttribute {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The unique identifier of the node",
+		},
+		"key": schema.StringAttribute{
+			Computed:    true,
+			Description: "The position of the node in the tree",
+		},
+		"value": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the node",
+		},
+		"full_value": schema.StringAttribute{
+			Computed:    true,
+			Description: "The full path of the node",
+		},
+		"asset_count": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The number of assets in the node and its descendants",
+		},
+		"direct_asset_count": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The number of assets attached directly to the node",
+		},
+	}
+	if level < maxNodeTreeDepth {
+		attributes["children"] = nodeTreeChildrenAttribute(level + 1)
+	}
+
+	return schema.ListNestedAttribute{
+		Computed:    true,
+		Description: "The child nodes, sorted by key",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: attributes,
+		},
+	}
+}
+
+// nodeTreeObjectType returns the object type of a node at the given level
+func nodeTreeObjectType(level int) types.ObjectType {
+	attrTypes := map[string]attr.Type{
+		"id":                 types.StringType,
+		"key":                types.StringType,
+		"value":              types.StringType,
+		"full_value":         types.StringType,
+		"asset_count":        types.Int64Type,
+		"direct_asset_count": types.Int64Type,
+	}
+	if level < maxNodeTreeDepth {
+		attrTypes["children"] = types.ListType{ElemType: nodeTreeObjectType(level + 1)}
+	}
+	return types.ObjectType{AttrTypes: attrTypes}
+}
+
+func (d *NodeTreeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// nodeTree holds the subtree and per-node asset counts while the state is built
+type nodeTree struct {
+	children map[string][]jumpserver.Node
+	direct   map[string]int
+	total    map[string]int
+}
+
+func (d *NodeTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config NodeTreeDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = jumpserver.WithOrgID(ctx, config.OrgID.ValueString())
+
+	depth := 1
+	if !config.Depth.IsNull() {
+		depth = int(config.Depth.ValueInt64())
+	}
+
+	nodes, err := d.client.ListNodes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading node tree",
+			fmt.Sprintf("Could not list nodes: %s", err),
+		)
+		return
+	}
+
+	rootRef := config.Root.ValueString()
+	var root *jumpserver.Node
+	for i := range nodes {
+		if nodes[i].ID == rootRef || nodes[i].FullName == rootRef {
+			root = &nodes[i]
+			break
+		}
+	}
+	if root == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("root"),
+			"Node not found",
+			fmt.Sprintf("No node with ID or full path %q exists.", rootRef),
+		)
+		return
+	}
+
+	assets, err := d.client.ListNodeAssets(ctx, root.ID, true)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading node tree",
+			fmt.Sprintf("Could not list the assets of node %s: %s", root.FullName, err),
+		)
+		return
+	}
+
+	tree := buildNodeTree(root, nodes, assets)
+
+	config.ID = types.StringValue(root.ID)
+	config.Key = types.StringValue(root.Key)
+	config.Value = types.StringValue(root.Value)
+	config.FullValue = types.StringValue(root.FullName)
+	config.AssetCount = types.Int64Value(int64(tree.total[root.Key]))
+	config.DirectAssetCount = types.Int64Value(int64(tree.direct[root.Key]))
+
+	config.Children, diags = tree.childrenValue(root.Key, 1, depth)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assetIDs := []string{}
+	for _, a := range assets {
+		if config.Recursive.ValueBool() || assetInNode(a, root.ID) {
+			assetIDs = append(assetIDs, a.ID)
+		}
+	}
+	config.AssetIDs, diags = types.SetValueFrom(ctx, types.StringType, assetIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read node tree data source", map[string]any{"id": root.ID, "assets": len(assetIDs)})
+
+	diags = resp.State.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// nodeKeyLess orders node keys by their colon-separated segments compared as numbers,
+// so "1:2" comes before "1:10". Segments that are not numbers are compared as strings.
+func nodeKeyLess(a, b string) bool {
+	as, bs := strings.Split(a, ":"), strings.Split(b, ":")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		if aErr != nil || bErr != nil {
+			return as[i] < bs[i]
+		}
+		return an < bn
+	}
+	return len(as) < len(bs)
+}
+
+// buildNodeTree indexes the subtree below root and counts its assets per node. An asset
+// counts once towards every node on the path from its nodes up to the root.
+func buildNodeTree(root *jumpserver.Node, nodes []jumpserver.Node, assets []jumpserver.Asset) *nodeTree {
+	tree := &nodeTree{
+		children: make(map[string][]jumpserver.Node),
+		direct:   make(map[string]int),
+		total:    make(map[string]int),
+	}
+
+	keyByID := make(map[string]string, len(nodes))
+	for _, n := range nodes {
+		keyByID[n.ID] = n.Key
+		if n.IsDescendantOf(root.Key) {
+			parent := n.ParentKey()
+			tree.children[parent] = append(tree.children[parent], n)
+		}
+	}
+	for _, children := range tree.children {
+		sort.Slice(children, func(i, j int) bool { return nodeKeyLess(children[i].Key, children[j].Key) })
+	}
+
+	for _, a := range assets {
+		counted := make(map[string]bool)
+		for _, n := range a.Nodes {
+			key, ok := keyByID[n.ID]
+			if !ok || (key != root.Key && !strings.HasPrefix(key, root.Key+":")) {
+				continue
+			}
+			tree.direct[key]++
+
+			// Walk up to the root, counting the asset once per ancestor
+			for k := key; ; k = k[:strings.LastIndex(k, ":")] {
+				if !counted[k] {
+					counted[k] = true
+					tree.total[k]++
+				}
+				if k == root.Key || !strings.Contains(k, ":") {
+					break
+				}
+			}
+		}
+	}
+
+	return tree
+}
+
+// childrenValue builds the children list of the node with the given key. Levels beyond
+// depth are returned as empty lists.
+func (t *nodeTree) childrenValue(key string, level, depth int) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	objectType := nodeTreeObjectType(level)
+
+	var elements []attr.Value
+	if level <= depth {
+		for _, n := range t.children[key] {
+			attrs := map[string]attr.Value{
+				"id":                 types.StringValue(n.ID),
+				"key":                types.StringValue(n.Key),
+				"value":              types.StringValue(n.Value),
+				"full_value":         types.StringValue(n.FullName),
+				"asset_count":        types.Int64Value(int64(t.total[n.Key])),
+				"direct_asset_count": types.Int64Value(int64(t.direct[n.Key])),
+			}
+			if level < maxNodeTreeDepth {
+				children, d := t.childrenValue(n.Key, level+1, depth)
+				diags.Append(d...)
+				attrs["children"] = children
+			}
+
+			obj, d := types.ObjectValue(objectType.AttrTypes, attrs)
+			diags.Append(d...)
+			elements = append(elements, obj)
+		}
+	}
+
+	list, d := types.ListValue(objectType, elements)
+	diags.Append(d...)
+	return list, diags
+}
+
+// assetInNode reports whether the asset is attached directly to the node
+func assetInNode(asset jumpserver.Asset, nodeID string) bool {
+	for _, n := range asset.Nodes {
+		if n.ID == nodeID {
+			return true
+		}
+	}
+	return false
+}
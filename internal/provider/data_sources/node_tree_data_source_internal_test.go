@@ -0,0 +1,96 @@
+package data_sources
+
+import (
+	"testing"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestBuildNodeTreeCountsAssets(t *testing.T) {
+	nodes := []jumpserver.Node{
+		{ID: "n0", Key: "1", FullName: "/Default"},
+		{ID: "n1", Key: "1:1", FullName: "/Default/Prod"},
+		{ID: "n2", Key: "1:1:1", FullName: "/Default/Prod/Payments"},
+		{ID: "n3", Key: "1:1:2", FullName: "/Default/Prod/Web"},
+		{ID: "n4", Key: "1:1:1:1", FullName: "/Default/Prod/Payments/DB"},
+		{ID: "n5", Key: "1:2", FullName: "/Default/Staging"},
+	}
+	assets := []jumpserver.Asset{
+		{ID: "a1", Nodes: []jumpserver.Node{{ID: "n2"}, {ID: "n4"}}},
+		{ID: "a2", Nodes: []jumpserver.Node{{ID: "n1"}, {ID: "n5"}}},
+		{ID: "a3", Nodes: []jumpserver.Node{{ID: "n3"}}},
+	}
+
+	tree := buildNodeTree(&nodes[1], nodes, assets)
+
+	tests := []struct {
+		key           string
+		total, direct int
+	}{
+		{"1:1", 3, 1},
+		{"1:1:1", 1, 1},
+		{"1:1:1:1", 1, 1},
+		{"1:1:2", 1, 1},
+		{"1:2", 0, 0},
+	}
+	for _, tt := range tests {
+		if tree.total[tt.key] != tt.total || tree.direct[tt.key] != tt.direct {
+			t.Errorf("node %s: expected total %d and direct %d, got %d and %d",
+				tt.key, tt.total, tt.direct, tree.total[tt.key], tree.direct[tt.key])
+		}
+	}
+
+	if children := tree.children["1:1"]; len(children) != 2 || children[0].ID != "n2" {
+		t.Errorf("expected children n2 and n3 below the root, got %v", children)
+	}
+	if _, ok := tree.children["1"]; ok {
+		t.Error("expected nodes outside the subtree to be ignored")
+	}
+}
+
+func TestBuildNodeTreeSortsChildrenNumerically(t *testing.T) {
+	root := &jumpserver.Node{ID: "n0", Key: "1"}
+	nodes := []jumpserver.Node{
+		*root,
+		{ID: "n10", Key: "1:10"},
+		{ID: "n2", Key: "1:2"},
+		{ID: "n9", Key: "1:9"},
+		{ID: "n1", Key: "1:1"},
+	}
+
+	tree := buildNodeTree(root, nodes, nil)
+
+	var got []string
+	for _, n := range tree.children["1"] {
+		got = append(got, n.Key)
+	}
+	want := []string{"1:1", "1:2", "1:9", "1:10"}
+	if len(got) != len(want) {
+		t.Fatalf("expected children %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected children %v, got %v", want, got)
+		}
+	}
+}
+
+func TestNodeKeyLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1:2", "1:10", true},
+		{"1:10", "1:2", false},
+		{"1:2:5", "1:10", true},
+		{"1", "1:1", true},
+		{"1:1", "1:1", false},
+		{"1:a", "1:b", true},
+	}
+
+	for _, tt := range tests {
+		if got := nodeKeyLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("nodeKeyLess(%q, %q): expected %t, got %t", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
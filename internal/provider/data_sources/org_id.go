@@ -0,0 +1,13 @@
+package data_sources
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// orgIDAttribute returns the org_id attribute shared by every data source
+func orgIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "ID of the organization to look in. Overrides the provider org_id for this data source",
+	}
+}
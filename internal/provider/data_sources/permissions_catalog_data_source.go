@@ -0,0 +1,163 @@
+package data_sources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ datasource.DataSource              = &PermissionsCatalogDataSource{}
+	_ datasource.DataSourceWithConfigure = &PermissionsCatalogDataSource{}
+)
+
+func NewPermissionsCatalogDataSource() datasource.DataSource {
+	return &PermissionsCatalogDataSource{}
+}
+
+type PermissionsCatalogDataSource struct {
+	client *jumpserver.Client
+}
+
+type PermissionsCatalogDataSourceModel struct {
+	Scope       types.String `tfsdk:"scope"`
+	Codenames   types.Set    `tfsdk:"codenames"`
+	Permissions types.List   `tfsdk:"permissions"`
+}
+
+// CatalogPermissionModel describes a permission in the catalog
+type CatalogPermissionModel struct {
+	ID       types.Int64  `tfsdk:"id"`
+	Codename types.String `tfsdk:"codename"`
+	Name     types.String `tfsdk:"name"`
+	AppLabel types.String `tfsdk:"app_label"`
+}
+
+var catalogPermissionAttrTypes = map[string]attr.Type{
+	"id":        types.Int64Type,
+	"codename":  types.StringType,
+	"name":      types.StringType,
+	"app_label": types.StringType,
+}
+
+func (d *PermissionsCatalogDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permissions_catalog"
+}
+
+func (d *PermissionsCatalogDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the RBAC permission codenames available on the server, for use in jumpserver_role. The catalog is the same in every organization, so the data source has no org_id",
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list permissions that roles of this scope can grant: 'system' or 'org'. Lists every permission when unset",
+				Validators: []validator.String{
+					stringvalidator.OneOf(jumpserver.RoleScopeSystem, jumpserver.RoleScopeOrg),
+				},
+			},
+			"codenames": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Permission codenames in app_label.codename form (e.g., 'assets.view_asset')",
+			},
+			"permissions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The permissions, sorted by codename",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The numeric identifier of the permission",
+						},
+						"codename": schema.StringAttribute{
+							Computed:    true,
+							Description: "The codename in app_label.codename form",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The human-readable name of the permission",
+						},
+						"app_label": schema.StringAttribute{
+							Computed:    true,
+							Description: "The application the permission belongs to (e.g., 'assets')",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PermissionsCatalogDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PermissionsCatalogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PermissionsCatalogDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissions, err := d.client.ListRBACPermissions(ctx, config.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading permissions catalog",
+			fmt.Sprintf("Could not list permissions: %s", err),
+		)
+		return
+	}
+
+	sort.Slice(permissions, func(i, j int) bool {
+		return permissions[i].FullCodename() < permissions[j].FullCodename()
+	})
+
+	codenames := make([]string, 0, len(permissions))
+	models := make([]CatalogPermissionModel, 0, len(permissions))
+	for _, p := range permissions {
+		codenames = append(codenames, p.FullCodename())
+		models = append(models, CatalogPermissionModel{
+			ID:       types.Int64Value(int64(p.ID)),
+			Codename: types.StringValue(p.FullCodename()),
+			Name:     types.StringValue(p.Name),
+			AppLabel: types.StringValue(p.GetAppLabel()),
+		})
+	}
+
+	config.Codenames, diags = types.SetValueFrom(ctx, types.StringType, codenames)
+	resp.Diagnostics.Append(diags...)
+	config.Permissions, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: catalogPermissionAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read permissions catalog data source", map[string]any{"count": len(permissions)})
+
+	diags = resp.State.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
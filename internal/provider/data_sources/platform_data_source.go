@@ -31,6 +31,7 @@ type PlatformDataSourceModel struct {
 	DisplayName types.String `tfsdk:"display_name"`
 	Type        types.String `tfsdk:"type"`
 	Category    types.String `tfsdk:"category"`
+	OrgID       types.String `tfsdk:"org_id"`
 }
 
 func (d *PlatformDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -61,6 +62,7 @@ func (d *PlatformDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				Computed:    true,
 				Description: "The platform category",
 			},
+			"org_id": orgIDAttribute(),
 		},
 	}
 }
@@ -90,7 +92,9 @@ func (d *PlatformDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	platform, err := d.client.GetPlatformByName(config.ID.ValueString())
+	ctx = jumpserver.WithOrgID(ctx, config.OrgID.ValueString())
+
+	platform, err := d.client.GetPlatformByName(ctx, config.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading platform",
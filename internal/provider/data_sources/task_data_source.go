@@ -30,6 +30,7 @@ type TaskDataSourceModel struct {
 	Output   types.String `tfsdk:"output"`
 	Finished types.Bool   `tfsdk:"finished"`
 	Mark     types.String `tfsdk:"mark"`
+	OrgID    types.String `tfsdk:"org_id"`
 }
 
 func (d *TaskDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -56,6 +57,7 @@ func (d *TaskDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:    true,
 				Description: "The mark identifier for the task execution",
 			},
+			"org_id": orgIDAttribute(),
 		},
 	}
 }
@@ -85,7 +87,9 @@ func (d *TaskDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	task, err := d.client.GetCommandExecution(config.ID.ValueString())
+	ctx = jumpserver.WithOrgID(ctx, config.OrgID.ValueString())
+
+	task, err := d.client.GetCommandExecution(ctx, config.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading task execution",
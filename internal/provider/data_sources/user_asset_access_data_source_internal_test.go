@@ -0,0 +1,68 @@
+package data_sources
+
+import (
+	"testing"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestUserAssetAccessValue(t *testing.T) {
+	assets := []jumpserver.PermedAsset{
+		{
+			Asset: jumpserver.Asset{ID: "a2", Name: "web", Addrs: "10.0.0.2", Platform: jumpserver.Platform{Name: "Linux"}},
+			PermedAccounts: []jumpserver.PermedAccount{
+				{Name: "root", Username: "root", Alias: "acc1", SecretType: map[string]interface{}{"value": "password"}, Actions: []interface{}{float64(3)}},
+				{Name: "Manual input", Alias: "@INPUT", Actions: []interface{}{map[string]interface{}{"value": "all"}}},
+			},
+			PermedProtocols: []jumpserver.Protocol{{Name: "ssh", Port: 22}, {Name: "sftp", Port: 22}},
+		},
+		{Asset: jumpserver.Asset{ID: "a1", Name: "db", Address: "10.0.0.1"}},
+	}
+
+	list, diags := userAssetAccessValue(assets)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var got []struct {
+		ID        string   `tfsdk:"id"`
+		Name      string   `tfsdk:"name"`
+		Address   string   `tfsdk:"address"`
+		Platform  string   `tfsdk:"platform"`
+		Protocols []string `tfsdk:"protocols"`
+		Accounts  []struct {
+			Name       string   `tfsdk:"name"`
+			Username   string   `tfsdk:"username"`
+			Alias      string   `tfsdk:"alias"`
+			SecretType string   `tfsdk:"secret_type"`
+			Actions    []string `tfsdk:"actions"`
+		} `tfsdk:"accounts"`
+	}
+	if diags := list.ElementsAs(t.Context(), &got, false); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(got) != 2 || got[0].ID != "a1" || got[1].ID != "a2" {
+		t.Fatalf("expected assets sorted by name, got %+v", got)
+	}
+	if got[0].Address != "10.0.0.1" || got[1].Address != "10.0.0.2" || got[1].Platform != "Linux" {
+		t.Errorf("unexpected asset fields: %+v", got)
+	}
+	if len(got[1].Protocols) != 2 {
+		t.Errorf("expected ssh and sftp, got %v", got[1].Protocols)
+	}
+
+	accounts := got[1].Accounts
+	if len(accounts) != 2 || accounts[0].Alias != "@INPUT" || accounts[1].Name != "root" {
+		t.Fatalf("expected accounts sorted by name, got %+v", accounts)
+	}
+	if len(accounts[0].Actions) != len(jumpserver.Actions) {
+		t.Errorf("expected 'all' to expand into every action, got %v", accounts[0].Actions)
+	}
+	if a := accounts[1].Actions; len(a) != 2 || a[0] != "connect" || a[1] != "upload" || accounts[1].SecretType != "password" {
+		t.Errorf("expected bitmask 3 to decode to connect and upload with a password, got %+v", accounts[1])
+	}
+	if len(got[0].Accounts) != 0 {
+		t.Errorf("expected no accounts, got %+v", got[0].Accounts)
+	}
+}
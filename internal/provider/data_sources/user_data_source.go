@@ -32,6 +32,8 @@ type UserDataSourceModel struct {
 	Email    types.String `tfsdk:"email"`
 	IsActive types.Bool   `tfsdk:"is_active"`
 	Comment  types.String `tfsdk:"comment"`
+	Groups   types.Set    `tfsdk:"groups"`
+	OrgID    types.String `tfsdk:"org_id"`
 }
 
 func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -66,6 +68,12 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:    true,
 				Description: "Additional comments",
 			},
+			"groups": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the user groups the user belongs to",
+			},
+			"org_id": orgIDAttribute(),
 		},
 	}
 }
@@ -95,7 +103,9 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	user, err := d.client.GetUser(config.ID.ValueString())
+	ctx = jumpserver.WithOrgID(ctx, config.OrgID.ValueString())
+
+	user, err := d.client.GetUser(ctx, config.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading user",
@@ -110,6 +120,11 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	config.Email = types.StringValue(user.Email)
 	config.IsActive = types.BoolValue(user.IsActive)
 	config.Comment = types.StringValue(user.Comment)
+	config.Groups, diags = types.SetValueFrom(ctx, types.StringType, user.GetGroupIDs())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Trace(ctx, "read user data source", map[string]any{"id": config.ID.ValueString()})
 
@@ -0,0 +1,131 @@
+package data_sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ datasource.DataSource              = &UserGroupDataSource{}
+	_ datasource.DataSourceWithConfigure = &UserGroupDataSource{}
+)
+
+func NewUserGroupDataSource() datasource.DataSource {
+	return &UserGroupDataSource{}
+}
+
+type UserGroupDataSource struct {
+	client *jumpserver.Client
+}
+
+type UserGroupDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Comment types.String `tfsdk:"comment"`
+	Users   types.Set    `tfsdk:"users"`
+	OrgID   types.String `tfsdk:"org_id"`
+}
+
+func (d *UserGroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_group"
+}
+
+func (d *UserGroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves a JumpServer user group by ID or name",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The unique identifier of the user group. Exactly one of id or name must be set",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The exact name of the user group",
+			},
+			"comment": schema.StringAttribute{
+				Computed:    true,
+				Description: "Additional comments",
+			},
+			"users": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the members of the group",
+			},
+			"org_id": orgIDAttribute(),
+		},
+	}
+}
+
+func (d *UserGroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UserGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UserGroupDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = jumpserver.WithOrgID(ctx, config.OrgID.ValueString())
+
+	var group *jumpserver.UserGroup
+	var err error
+	ref := config.ID.ValueString()
+	if ref != "" {
+		group, err = d.client.GetUserGroup(ctx, ref)
+	} else {
+		ref = config.Name.ValueString()
+		group, err = d.client.GetUserGroupByName(ctx, ref)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading user group",
+			fmt.Sprintf("Could not read user group %s: %s", ref, err),
+		)
+		return
+	}
+
+	config.ID = types.StringValue(group.ID)
+	config.Name = types.StringValue(group.Name)
+	config.Comment = types.StringValue(group.Comment)
+	config.Users, diags = types.SetValueFrom(ctx, types.StringType, group.GetUserIDs())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read user group data source", map[string]any{"id": config.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -1,13 +1,18 @@
 package provider
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"jumpserver/internal/jumpserver"
@@ -33,6 +38,10 @@ type JumpServerProviderModel struct {
 	KeySecret          types.String `tfsdk:"key_secret"`
 	OrgID              types.String `tfsdk:"org_id"`
 	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	PageSize           types.Int64  `tfsdk:"page_size"`
+	MaxRetries         types.Int64  `tfsdk:"max_retries"`
+	RetryMinBackoff    types.String `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff    types.String `tfsdk:"retry_max_backoff"`
 }
 
 func (p *JumpServerProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -66,6 +75,28 @@ func (p *JumpServerProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Description: "Skip TLS certificate verification (not recommended for production)",
 				Optional:    true,
 			},
+			"page_size": schema.Int64Attribute{
+				Description: "Number of items requested per page when listing objects (optional, defaults to 100)",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of retries for transient API failures such as 429, 502, 503 and 504 (optional, defaults to 3, 0 disables retries)",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				Description: "Wait before the first retry as a Go duration, doubled on each further attempt. Example: 500ms (optional, defaults to 1s)",
+				Optional:    true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Description: "Upper bound for the wait between retries as a Go duration, including waits requested by the server through Retry-After. Must not be below retry_min_backoff. Example: 1m (optional, defaults to 30s, or retry_min_backoff when that is longer)",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -144,12 +175,62 @@ func (p *JumpServerProvider) Configure(ctx context.Context, req provider.Configu
 
 	insecureSkipVerify := !config.InsecureSkipVerify.IsNull() && config.InsecureSkipVerify.ValueBool()
 
+	pageSize := 0
+	if !config.PageSize.IsNull() {
+		pageSize = int(config.PageSize.ValueInt64())
+	}
+
+	maxRetries := jumpserver.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	var retryMinBackoff, retryMaxBackoff time.Duration
+	if !config.RetryMinBackoff.IsNull() {
+		d, err := time.ParseDuration(config.RetryMinBackoff.ValueString())
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_min_backoff"),
+				"Invalid Retry Backoff",
+				fmt.Sprintf("The retry_min_backoff value %q is not a valid positive duration such as \"500ms\" or \"2s\".", config.RetryMinBackoff.ValueString()),
+			)
+			return
+		}
+		retryMinBackoff = d
+	}
+
+	if !config.RetryMaxBackoff.IsNull() {
+		d, err := time.ParseDuration(config.RetryMaxBackoff.ValueString())
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Invalid Retry Backoff",
+				fmt.Sprintf("The retry_max_backoff value %q is not a valid positive duration such as \"30s\" or \"1m\".", config.RetryMaxBackoff.ValueString()),
+			)
+			return
+		}
+		retryMaxBackoff = d
+
+		if minBackoff := cmp.Or(retryMinBackoff, jumpserver.DefaultRetryMinBackoff); d < minBackoff {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Invalid Retry Backoff",
+				fmt.Sprintf("The retry_max_backoff value %s is below the retry_min_backoff of %s.", d, minBackoff),
+			)
+			return
+		}
+	}
+
 	client := jumpserver.NewClient(&jumpserver.Config{
 		Endpoint:           endpoint,
 		KeyID:              keyID,
 		KeySecret:          keySecret,
 		OrgID:              orgID,
 		InsecureSkipVerify: insecureSkipVerify,
+		PageSize:           pageSize,
+		MaxRetries:         maxRetries,
+		RetryMinBackoff:    retryMinBackoff,
+		RetryMaxBackoff:    retryMaxBackoff,
 	})
 
 	resp.DataSourceData = client
@@ -160,8 +241,17 @@ func (p *JumpServerProvider) Resources(ctx context.Context) []func() resource.Re
 	return []func() resource.Resource{
 		resources.NewAssetResource,
 		resources.NewAccountResource,
+		resources.NewAccountTemplateResource,
 		resources.NewPermissionResource,
 		resources.NewUserResource,
+		resources.NewLabelResource,
+		resources.NewNodeResource,
+		resources.NewPlatformResource,
+		resources.NewOrganizationResource,
+		resources.NewUserGroupResource,
+		resources.NewUserGroupMembershipResource,
+		resources.NewRoleResource,
+		resources.NewRoleBindingResource,
 	}
 }
 
@@ -171,6 +261,11 @@ func (p *JumpServerProvider) DataSources(ctx context.Context) []func() datasourc
 		data_sources.NewPlatformDataSource,
 		data_sources.NewNodeDataSource,
 		data_sources.NewUserDataSource,
+		data_sources.NewLabelsDataSource,
+		data_sources.NewNodeTreeDataSource,
+		data_sources.NewUserGroupDataSource,
+		data_sources.NewPermissionsCatalogDataSource,
+		data_sources.NewUserAssetAccessDataSource,
 	}
 }
 
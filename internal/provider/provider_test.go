@@ -1,8 +1,15 @@
 package provider_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
 	providerpkg "jumpserver/internal/provider"
 )
 
@@ -24,10 +31,88 @@ func TestProvider(t *testing.T) {
 	_ = providerpkg.New("dev")()
 }
 
+func TestProvider_Schemas(t *testing.T) {
+	ctx := context.Background()
+	p := providerpkg.New("dev")()
+
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		metadata := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "jumpserver"}, metadata)
+
+		resp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, resp)
+		resp.Diagnostics.Append(resp.Schema.ValidateImplementation(ctx)...)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("resource %s has an invalid schema: %v", metadata.TypeName, resp.Diagnostics)
+		}
+	}
+
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+		metadata := &datasource.MetadataResponse{}
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "jumpserver"}, metadata)
+
+		resp := &datasource.SchemaResponse{}
+		d.Schema(ctx, datasource.SchemaRequest{}, resp)
+		resp.Diagnostics.Append(resp.Schema.ValidateImplementation(ctx)...)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("data source %s has an invalid schema: %v", metadata.TypeName, resp.Diagnostics)
+		}
+	}
+}
+
 func TestProvider_Configure(t *testing.T) {
 	t.Skip("Skipping - requires JumpServer instance and framework compatibility update")
 }
 
+func TestProviderConfigureChecksRetryBackoffs(t *testing.T) {
+	ctx := context.Background()
+	p := providerpkg.New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := []struct {
+		name       string
+		min, max   string
+		wantErrors bool
+	}{
+		{"defaults", "", "", false},
+		{"both set", "500ms", "1m", false},
+		{"zero minimum", "0s", "", true},
+		{"zero maximum", "", "0s", true},
+		{"maximum below minimum", "10s", "5s", true},
+		{"maximum below default minimum", "", "500ms", true},
+	}
+
+	for _, tt := range tests {
+		attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attrType := range objectType.AttributeTypes {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+		attrs["endpoint"] = tftypes.NewValue(tftypes.String, "https://jumpserver.test")
+		attrs["key_id"] = tftypes.NewValue(tftypes.String, "test-key-id")
+		attrs["key_secret"] = tftypes.NewValue(tftypes.String, "test-key-secret")
+		if tt.min != "" {
+			attrs["retry_min_backoff"] = tftypes.NewValue(tftypes.String, tt.min)
+		}
+		if tt.max != "" {
+			attrs["retry_max_backoff"] = tftypes.NewValue(tftypes.String, tt.max)
+		}
+
+		req := provider.ConfigureRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attrs)},
+		}
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, req, resp)
+		if resp.Diagnostics.HasError() != tt.wantErrors {
+			t.Errorf("%s: expected error %t, got %v", tt.name, tt.wantErrors, resp.Diagnostics)
+		}
+	}
+}
+
 func testAccProviderExists() {
 	// Verify provider configuration was successful
 }
@@ -3,9 +3,11 @@ package resources
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,9 +20,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &AccountResource{}
-	_ resource.ResourceWithConfigure   = &AccountResource{}
-	_ resource.ResourceWithImportState = &AccountResource{}
+	_ resource.Resource                   = &AccountResource{}
+	_ resource.ResourceWithConfigure      = &AccountResource{}
+	_ resource.ResourceWithImportState    = &AccountResource{}
+	_ resource.ResourceWithValidateConfig = &AccountResource{}
+	_ resource.ResourceWithModifyPlan     = &AccountResource{}
 )
 
 func NewAccountResource() resource.Resource {
@@ -32,12 +36,19 @@ type AccountResource struct {
 }
 
 type AccountResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"username"`
-	Asset      types.String `tfsdk:"asset"`
-	Secret     types.String `tfsdk:"secret"`
-	SecretType types.String `tfsdk:"secret_type"`
-	Comment    types.String `tfsdk:"comment"`
+	ID                   types.String   `tfsdk:"id"`
+	Name                 types.String   `tfsdk:"username"`
+	Asset                types.String   `tfsdk:"asset"`
+	Secret               types.String   `tfsdk:"secret"`
+	SecretType           types.String   `tfsdk:"secret_type"`
+	Passphrase           types.String   `tfsdk:"passphrase"`
+	Template             types.String   `tfsdk:"template"`
+	SecretVersion        types.Int64    `tfsdk:"secret_version"`
+	GenerateSecret       types.Bool     `tfsdk:"generate_secret"`
+	PublicKeyFingerprint types.String   `tfsdk:"public_key_fingerprint"`
+	Comment              types.String   `tfsdk:"comment"`
+	OrgID                types.String   `tfsdk:"org_id"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *AccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,17 +67,22 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Description: "The unique identifier of the account",
 			},
 			"username": schema.StringAttribute{
-				Required:    true,
-				Description: "The username",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The username. Required unless template is set, in which case it defaults to the template username",
 			},
 			"asset": schema.StringAttribute{
 				Required:    true,
 				Description: "The asset ID to associate the account with",
 			},
 			"secret": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "The password or secret for the account",
+				WriteOnly:   true,
+				Description: "The password or secret for the account. For 'ssh_key' accounts, a PEM or OpenSSH private key, which is validated before it is sent. Required unless generate_secret is set. Write-only: it is sent when the account is created or secret_version changes and never stored in state",
 			},
 			"secret_type": schema.StringAttribute{
 				Optional:    true,
@@ -76,10 +92,44 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringvalidator.OneOf("password", "ssh_key", "access_key", "token"),
 				},
 			},
+			"passphrase": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The passphrase of an encrypted private key. Only valid for 'ssh_key' accounts. Write-only: it is sent together with secret",
+			},
+			"template": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of an account template to create the account from. The template supplies the username, secret and privileges that are not set here. Changing it forces a new account",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"secret_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Change this value to push the current secret and passphrase to JumpServer. Changing secret alone is not detected, since write-only values are not stored",
+			},
+			"generate_secret": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Let JumpServer generate a random secret of secret_type when the account is created, following the secret rules of the asset platform. Cannot be combined with secret",
+			},
+			"public_key_fingerprint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The SHA256 fingerprint of the public key of an 'ssh_key' account (e.g., 'SHA256:...'). Generated keys are only fingerprinted when the provider may view account secrets",
+			},
 			"comment": schema.StringAttribute{
 				Optional:    true,
 				Description: "Additional comments about the account",
 			},
+			"org_id": orgIDAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -101,6 +151,96 @@ func (r *AccountResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+func (r *AccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AccountResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Name.IsNull() && config.Template.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Missing username",
+			"Set username, or set template to take it from an account template.",
+		)
+	}
+
+	if !config.Secret.IsUnknown() && !config.GenerateSecret.IsUnknown() {
+		switch {
+		case config.Secret.IsNull() && !config.GenerateSecret.ValueBool() && config.Template.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secret"),
+				"Missing secret",
+				"Set secret, set generate_secret to let JumpServer generate one, or set template to use the template secret.",
+			)
+		case !config.Secret.IsNull() && config.GenerateSecret.ValueBool():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("generate_secret"),
+				"Conflicting secret",
+				"generate_secret cannot be set together with secret.",
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(validateSSHKeySecret(config.SecretType, config.Secret, config.Passphrase, "account")...)
+}
+
+// ModifyPlan works out the public key fingerprint from the configured key so that a
+// changed key shows in the plan. Generated keys are only known after apply.
+func (r *AccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config AccountResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The secret is only pushed when the account is created or secret_version changes,
+	// so a changed key only changes the fingerprint then
+	if !req.State.Raw.IsNull() {
+		var state AccountResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if config.SecretVersion.Equal(state.SecretVersion) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("public_key_fingerprint"), state.PublicKeyFingerprint)...)
+			return
+		}
+		// Generated and template secrets are never sent, so there is nothing to rotate
+		if config.Secret.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secret_version"),
+				"Nothing to rotate",
+				"secret_version changed but secret is not set. Set secret to the new value, or rotate generated and template secrets in JumpServer.",
+			)
+			return
+		}
+	}
+
+	fingerprint := types.StringNull()
+	switch {
+	case config.Secret.IsUnknown() || config.Passphrase.IsUnknown() || config.SecretType.IsUnknown():
+		fingerprint = types.StringUnknown()
+	case config.SecretType.ValueString() != "ssh_key":
+		// Only keys have a fingerprint
+	case !config.Secret.IsNull():
+		// An invalid key is reported by ValidateConfig
+		if fp, err := sshKeyFingerprint(config.Secret.ValueString(), config.Passphrase.ValueString()); err == nil {
+			fingerprint = types.StringValue(fp)
+		}
+	case config.GenerateSecret.ValueBool():
+		fingerprint = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("public_key_fingerprint"), fingerprint)...)
+}
+
 func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan AccountResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -109,19 +249,45 @@ func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	// The secret and passphrase are write-only, so they are only available in the configuration
+	var secret, passphrase types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret"), &secret)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("passphrase"), &passphrase)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createReq := &jumpserver.CreateAccountRequest{
 		Name:       plan.Name.ValueString(),
 		Asset:      plan.Asset.ValueString(),
-		Secret:     plan.Secret.ValueString(),
+		Secret:     secret.ValueString(),
 		SecretType: plan.SecretType.ValueString(),
+		Passphrase: passphrase.ValueString(),
+		Template:   plan.Template.ValueString(),
 		Comment:    plan.Comment.ValueString(),
 	}
+	if plan.GenerateSecret.ValueBool() {
+		createReq.SecretStrategy = jumpserver.SecretStrategyRandom
+	}
 
-	account, err := r.client.CreateAccount(createReq)
+	account, err := r.client.CreateAccount(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
 			"Error creating account",
 			fmt.Sprintf("Could not create account: %s", err),
+			err,
 		)
 		return
 	}
@@ -132,6 +298,12 @@ func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest
 	plan.SecretType = types.StringValue(account.GetSecretTypeValue())
 	plan.Comment = types.StringValue(account.Comment)
 
+	if plan.PublicKeyFingerprint.IsUnknown() {
+		var d diag.Diagnostics
+		plan.PublicKeyFingerprint, d = r.generatedKeyFingerprint(ctx, account)
+		resp.Diagnostics.Append(d...)
+	}
+
 	tflog.Trace(ctx, "created account", map[string]any{"id": plan.ID.ValueString()})
 
 	diags = resp.State.Set(ctx, plan)
@@ -146,8 +318,25 @@ func (r *AccountResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	account, err := r.client.GetAccount(state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	account, err := r.client.GetAccount(ctx, state.ID.ValueString())
 	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "account no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error reading account",
 			fmt.Sprintf("Could not read account: %s", err),
@@ -166,25 +355,49 @@ func (r *AccountResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *AccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan AccountResourceModel
-	diags := req.Plan.Get(ctx, &plan)
+	var plan, state AccountResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
 	updateReq := &jumpserver.UpdateAccountRequest{
 		Name:       plan.Name.ValueString(),
-		Secret:     plan.Secret.ValueString(),
 		SecretType: plan.SecretType.ValueString(),
 		Comment:    plan.Comment.ValueString(),
 	}
 
-	account, err := r.client.UpdateAccount(plan.ID.ValueString(), updateReq)
+	// Rotate the secret when secret_version changes
+	if !plan.SecretVersion.Equal(state.SecretVersion) {
+		var secret, passphrase types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret"), &secret)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("passphrase"), &passphrase)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		updateReq.Secret = secret.ValueString()
+		updateReq.Passphrase = passphrase.ValueString()
+	}
+
+	account, err := r.client.UpdateAccount(ctx, plan.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
 			"Error updating account",
 			fmt.Sprintf("Could not update account: %s", err),
+			err,
 		)
 		return
 	}
@@ -192,6 +405,9 @@ func (r *AccountResource) Update(ctx context.Context, req resource.UpdateRequest
 	plan.Name = types.StringValue(account.Name)
 	plan.SecretType = types.StringValue(account.GetSecretTypeValue())
 	plan.Comment = types.StringValue(account.Comment)
+	if plan.PublicKeyFingerprint.IsUnknown() {
+		plan.PublicKeyFingerprint = types.StringNull()
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -205,8 +421,20 @@ func (r *AccountResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	err := r.client.DeleteAccount(state.ID.ValueString())
-	if err != nil {
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	err := r.client.DeleteAccount(ctx, state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting account",
 			fmt.Sprintf("Could not delete account: %s", err),
@@ -217,6 +445,33 @@ func (r *AccountResource) Delete(ctx context.Context, req resource.DeleteRequest
 	tflog.Trace(ctx, "deleted account", map[string]any{"id": state.ID.ValueString()})
 }
 
+// generatedKeyFingerprint fingerprints a key JumpServer generated for the account. The
+// key has to be read back, which needs permission to view account secrets; without it
+// the fingerprint is left empty with a warning.
+func (r *AccountResource) generatedKeyFingerprint(ctx context.Context, account *jumpserver.Account) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if account.GetSecretTypeValue() != "ssh_key" {
+		return types.StringNull(), diags
+	}
+
+	secret, err := r.client.GetAccountSecret(ctx, account.ID)
+	if err == nil {
+		var fp string
+		if fp, err = sshKeyFingerprint(secret.Secret, ""); err == nil {
+			return types.StringValue(fp), diags
+		}
+	}
+
+	tflog.Warn(ctx, "could not fingerprint generated key", map[string]any{"id": account.ID, "error": err.Error()})
+	diags.AddAttributeWarning(
+		path.Root("public_key_fingerprint"),
+		"Public key fingerprint unavailable",
+		fmt.Sprintf("JumpServer generated the key of account %s, but it could not be read back to compute its fingerprint: %s", account.Name, err),
+	)
+	return types.StringNull(), diags
+}
+
 func (r *AccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithOrgID(ctx, req, resp)
 }
@@ -0,0 +1,290 @@
+package resources
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/crypto/ssh"
+)
+
+// testSSHKey returns an unencrypted OpenSSH private key and its fingerprint
+func testSSHKey(t *testing.T) (string, string) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(block)), ssh.FingerprintSHA256(sshPub)
+}
+
+// testEncryptedSSHKey returns an OpenSSH private key encrypted with the passphrase
+func testEncryptedSSHKey(t *testing.T, passphrase string) string {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte(passphrase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(block))
+}
+
+func TestAccountValidateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    map[string]tftypes.Value
+		wantError bool
+	}{
+		{"password", map[string]tftypes.Value{
+			"secret": tftypes.NewValue(tftypes.String, "hunter2"),
+		}, false},
+		{"missing secret", map[string]tftypes.Value{}, true},
+		{"template", map[string]tftypes.Value{
+			"username": tftypes.NewValue(tftypes.String, nil),
+			"template": tftypes.NewValue(tftypes.String, "tpl1"),
+		}, false},
+		{"missing username", map[string]tftypes.Value{
+			"username": tftypes.NewValue(tftypes.String, nil),
+			"secret":   tftypes.NewValue(tftypes.String, "hunter2"),
+		}, true},
+	}
+
+	for _, tt := range tests {
+		values := map[string]tftypes.Value{"username": tftypes.NewValue(tftypes.String, "deploy")}
+		for name, value := range tt.values {
+			values[name] = value
+		}
+		config := resourceConfig(t, &AccountResource{}, values)
+
+		resp := &resource.ValidateConfigResponse{}
+		(&AccountResource{}).ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("%s: expected error %t, got %v", tt.name, tt.wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestAccountValidateConfigChecksSecrets(t *testing.T) {
+	key, _ := testSSHKey(t)
+	encrypted := testEncryptedSSHKey(t, "s3cret")
+
+	tests := []struct {
+		name   string
+		values map[string]tftypes.Value
+		// wantAttr is the attribute the error is reported on, empty when there is none
+		wantAttr string
+	}{
+		{"generated", map[string]tftypes.Value{
+			"generate_secret": tftypes.NewValue(tftypes.Bool, true),
+		}, ""},
+		{"secret and generate_secret", map[string]tftypes.Value{
+			"secret":          tftypes.NewValue(tftypes.String, "hunter2"),
+			"generate_secret": tftypes.NewValue(tftypes.Bool, true),
+		}, "generate_secret"},
+		{"ssh key", map[string]tftypes.Value{
+			"secret":      tftypes.NewValue(tftypes.String, key),
+			"secret_type": tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, ""},
+		{"encrypted ssh key", map[string]tftypes.Value{
+			"secret":      tftypes.NewValue(tftypes.String, encrypted),
+			"passphrase":  tftypes.NewValue(tftypes.String, "s3cret"),
+			"secret_type": tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, ""},
+		{"invalid ssh key", map[string]tftypes.Value{
+			"secret":      tftypes.NewValue(tftypes.String, "hunter2"),
+			"secret_type": tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, "secret"},
+		{"missing passphrase", map[string]tftypes.Value{
+			"secret":      tftypes.NewValue(tftypes.String, encrypted),
+			"secret_type": tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, "passphrase"},
+		{"passphrase on unencrypted key", map[string]tftypes.Value{
+			"secret":      tftypes.NewValue(tftypes.String, key),
+			"passphrase":  tftypes.NewValue(tftypes.String, "s3cret"),
+			"secret_type": tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, "passphrase"},
+		{"passphrase on password", map[string]tftypes.Value{
+			"secret":     tftypes.NewValue(tftypes.String, "hunter2"),
+			"passphrase": tftypes.NewValue(tftypes.String, "s3cret"),
+		}, "passphrase"},
+	}
+
+	for _, tt := range tests {
+		values := map[string]tftypes.Value{"username": tftypes.NewValue(tftypes.String, "deploy")}
+		for name, value := range tt.values {
+			values[name] = value
+		}
+		config := resourceConfig(t, &AccountResource{}, values)
+
+		resp := &resource.ValidateConfigResponse{}
+		(&AccountResource{}).ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+		errs := resp.Diagnostics.Errors()
+		switch {
+		case tt.wantAttr == "" && len(errs) > 0:
+			t.Errorf("%s: unexpected errors %v", tt.name, errs)
+		case tt.wantAttr != "" && len(errs) != 1:
+			t.Errorf("%s: expected one error on %s, got %v", tt.name, tt.wantAttr, errs)
+		case tt.wantAttr != "" && !errs[0].(diag.DiagnosticWithPath).Path().Equal(path.Root(tt.wantAttr)):
+			t.Errorf("%s: expected the error on %s, got %s", tt.name, tt.wantAttr, errs[0].(diag.DiagnosticWithPath).Path())
+		}
+	}
+}
+
+func TestAccountModifyPlanComputesFingerprintOnCreate(t *testing.T) {
+	ctx := context.Background()
+	key, fingerprint := testSSHKey(t)
+
+	tests := []struct {
+		name   string
+		values map[string]tftypes.Value
+		want   types.String
+	}{
+		{"ssh key", map[string]tftypes.Value{
+			"secret":      tftypes.NewValue(tftypes.String, key),
+			"secret_type": tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, types.StringValue(fingerprint)},
+		{"generated ssh key", map[string]tftypes.Value{
+			"generate_secret": tftypes.NewValue(tftypes.Bool, true),
+			"secret_type":     tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, types.StringUnknown()},
+		{"unknown secret", map[string]tftypes.Value{
+			"secret":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"secret_type": tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, types.StringUnknown()},
+		{"password", map[string]tftypes.Value{
+			"secret":      tftypes.NewValue(tftypes.String, "hunter2"),
+			"secret_type": tftypes.NewValue(tftypes.String, "password"),
+		}, types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		configRaw, accountSchema := resourceValue(t, &AccountResource{}, tt.values)
+		planRaw, _ := resourceValue(t, &AccountResource{}, map[string]tftypes.Value{
+			"id":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"secret_type":            tt.values["secret_type"],
+			"public_key_fingerprint": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		})
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: accountSchema, Raw: configRaw},
+			Plan:   tfsdk.Plan{Schema: accountSchema, Raw: planRaw},
+			State:  tfsdk.State{Schema: accountSchema, Raw: tftypes.NewValue(planRaw.Type(), nil)},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		(&AccountResource{}).ModifyPlan(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error %v", tt.name, resp.Diagnostics)
+		}
+
+		var got types.String
+		resp.Plan.GetAttribute(ctx, path.Root("public_key_fingerprint"), &got)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestAccountModifyPlanFollowsSecretVersion(t *testing.T) {
+	ctx := context.Background()
+	key, fingerprint := testSSHKey(t)
+
+	tests := []struct {
+		name         string
+		stateVersion int64
+		planVersion  int64
+		want         types.String
+	}{
+		{"unchanged version keeps fingerprint", 1, 1, types.StringValue("SHA256:old")},
+		{"bumped version uses new key", 1, 2, types.StringValue(fingerprint)},
+	}
+
+	for _, tt := range tests {
+		configured := map[string]tftypes.Value{
+			"id":             tftypes.NewValue(tftypes.String, "acc1"),
+			"secret_type":    tftypes.NewValue(tftypes.String, "ssh_key"),
+			"secret_version": tftypes.NewValue(tftypes.Number, tt.planVersion),
+		}
+		configRaw, accountSchema := resourceValue(t, &AccountResource{}, map[string]tftypes.Value{
+			"secret":         tftypes.NewValue(tftypes.String, key),
+			"secret_type":    configured["secret_type"],
+			"secret_version": configured["secret_version"],
+		})
+		planRaw, _ := resourceValue(t, &AccountResource{}, configured)
+		stateRaw, _ := resourceValue(t, &AccountResource{}, map[string]tftypes.Value{
+			"id":                     configured["id"],
+			"secret_type":            configured["secret_type"],
+			"secret_version":         tftypes.NewValue(tftypes.Number, tt.stateVersion),
+			"public_key_fingerprint": tftypes.NewValue(tftypes.String, "SHA256:old"),
+		})
+
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: accountSchema, Raw: configRaw},
+			Plan:   tfsdk.Plan{Schema: accountSchema, Raw: planRaw},
+			State:  tfsdk.State{Schema: accountSchema, Raw: stateRaw},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		(&AccountResource{}).ModifyPlan(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error %v", tt.name, resp.Diagnostics)
+		}
+
+		var got types.String
+		resp.Plan.GetAttribute(ctx, path.Root("public_key_fingerprint"), &got)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestAccountModifyPlanRejectsVersionBumpWithoutSecret(t *testing.T) {
+	ctx := context.Background()
+
+	configRaw, accountSchema := resourceValue(t, &AccountResource{}, map[string]tftypes.Value{
+		"generate_secret": tftypes.NewValue(tftypes.Bool, true),
+		"secret_version":  tftypes.NewValue(tftypes.Number, 2),
+	})
+	planRaw, _ := resourceValue(t, &AccountResource{}, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "acc1"),
+		"generate_secret": tftypes.NewValue(tftypes.Bool, true),
+		"secret_version":  tftypes.NewValue(tftypes.Number, 2),
+	})
+	stateRaw, _ := resourceValue(t, &AccountResource{}, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "acc1"),
+		"generate_secret": tftypes.NewValue(tftypes.Bool, true),
+		"secret_version":  tftypes.NewValue(tftypes.Number, 1),
+	})
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: accountSchema, Raw: configRaw},
+		Plan:   tfsdk.Plan{Schema: accountSchema, Raw: planRaw},
+		State:  tfsdk.State{Schema: accountSchema, Raw: stateRaw},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	(&AccountResource{}).ModifyPlan(ctx, req, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error when secret_version changes without a secret")
+	}
+	if got := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path(); !got.Equal(path.Root("secret_version")) {
+		t.Errorf("expected the error on secret_version, got %s", got)
+	}
+}
@@ -0,0 +1,570 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ resource.Resource                   = &AccountTemplateResource{}
+	_ resource.ResourceWithConfigure      = &AccountTemplateResource{}
+	_ resource.ResourceWithImportState    = &AccountTemplateResource{}
+	_ resource.ResourceWithValidateConfig = &AccountTemplateResource{}
+)
+
+func NewAccountTemplateResource() resource.Resource {
+	return &AccountTemplateResource{}
+}
+
+type AccountTemplateResource struct {
+	client *jumpserver.Client
+}
+
+type AccountTemplateResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Username       types.String   `tfsdk:"username"`
+	SecretType     types.String   `tfsdk:"secret_type"`
+	SecretStrategy types.String   `tfsdk:"secret_strategy"`
+	Secret         types.String   `tfsdk:"secret"`
+	Passphrase     types.String   `tfsdk:"passphrase"`
+	SecretVersion  types.Int64    `tfsdk:"secret_version"`
+	PasswordRules  types.Object   `tfsdk:"password_rules"`
+	Privileged     types.Bool     `tfsdk:"privileged"`
+	SuFrom         types.String   `tfsdk:"su_from"`
+	AutoPush       types.Bool     `tfsdk:"auto_push"`
+	Platforms      types.Set      `tfsdk:"platforms"`
+	IsActive       types.Bool     `tfsdk:"is_active"`
+	Comment        types.String   `tfsdk:"comment"`
+	OrgID          types.String   `tfsdk:"org_id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// PasswordRulesModel describes the passwords generated by the random secret strategy
+type PasswordRulesModel struct {
+	Length         types.Int64  `tfsdk:"length"`
+	Lowercase      types.Bool   `tfsdk:"lowercase"`
+	Uppercase      types.Bool   `tfsdk:"uppercase"`
+	Digit          types.Bool   `tfsdk:"digit"`
+	Symbol         types.Bool   `tfsdk:"symbol"`
+	ExcludeSymbols types.String `tfsdk:"exclude_symbols"`
+}
+
+var passwordRulesAttrTypes = map[string]attr.Type{
+	"length":          types.Int64Type,
+	"lowercase":       types.BoolType,
+	"uppercase":       types.BoolType,
+	"digit":           types.BoolType,
+	"symbol":          types.BoolType,
+	"exclude_symbols": types.StringType,
+}
+
+func (r *AccountTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_template"
+}
+
+func (r *AccountTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a JumpServer account template, a username and secret policy that accounts on many assets can be created from",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The unique identifier of the account template",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the account template",
+			},
+			"username": schema.StringAttribute{
+				Required:    true,
+				Description: "The username of the accounts created from the template",
+			},
+			"secret_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("password"),
+				Description: "The secret type (e.g., 'password', 'ssh_key', 'access_key'). Defaults to 'password'",
+				Validators: []validator.String{
+					stringvalidator.OneOf("password", "ssh_key", "access_key", "token"),
+				},
+			},
+			"secret_strategy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(jumpserver.SecretStrategySpecific),
+				Description: "How the secret is chosen: 'specific' uses secret, 'random' lets JumpServer generate one following password_rules. Defaults to 'specific'",
+				Validators: []validator.String{
+					stringvalidator.OneOf(jumpserver.SecretStrategySpecific, jumpserver.SecretStrategyRandom),
+				},
+			},
+			"secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The secret of the template, required when secret_strategy is 'specific'. For 'ssh_key' templates, a PEM or OpenSSH private key. Write-only: it is sent when the template is created or its secret_version or secret_strategy changes, and never stored in state",
+			},
+			"passphrase": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The passphrase of an encrypted private key. Only valid for 'ssh_key' templates. Write-only: it is sent together with secret",
+			},
+			"secret_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Change this value to push the current secret and passphrase to JumpServer. Only valid when secret_strategy is 'specific'",
+			},
+			"password_rules": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Rules for the passwords generated when secret_strategy is 'random'",
+				Attributes: map[string]schema.Attribute{
+					"length": schema.Int64Attribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(16),
+						Description: "The password length, from 8 to 36. Defaults to 16",
+						Validators: []validator.Int64{
+							int64validator.Between(8, 36),
+						},
+					},
+					"lowercase": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+						Description: "Whether passwords contain lowercase letters. Defaults to true",
+					},
+					"uppercase": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+						Description: "Whether passwords contain uppercase letters. Defaults to true",
+					},
+					"digit": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+						Description: "Whether passwords contain digits. Defaults to true",
+					},
+					"symbol": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+						Description: "Whether passwords contain symbols. Defaults to true",
+					},
+					"exclude_symbols": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString(""),
+						Description: "Symbols that never appear in generated passwords",
+					},
+				},
+			},
+			"privileged": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether accounts created from the template are privileged (e.g., root or Administrator)",
+			},
+			"su_from": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of another account template whose account is used to switch to this one with su",
+			},
+			"auto_push": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether JumpServer pushes the account to every asset of platforms",
+			},
+			"platforms": schema.SetAttribute{
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Description: "IDs of the platforms whose assets the account is pushed to when auto_push is set",
+			},
+			"is_active": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the template is active",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Additional comments about the account template",
+			},
+			"org_id": orgIDAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *AccountTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AccountTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AccountTemplateResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case config.SecretStrategy.IsUnknown():
+	case config.SecretStrategy.ValueString() == jumpserver.SecretStrategyRandom:
+		if !config.Secret.IsNull() && !config.Secret.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secret"),
+				"Unexpected secret",
+				"A secret cannot be set when secret_strategy is 'random'; JumpServer generates it.",
+			)
+		}
+		if !config.SecretVersion.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secret_version"),
+				"Unexpected secret_version",
+				"secret_version rotates the configured secret, so it can only be set when secret_strategy is 'specific'.",
+			)
+		}
+	default:
+		if config.Secret.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secret"),
+				"Missing secret",
+				"Set secret, or set secret_strategy to 'random' to let JumpServer generate it.",
+			)
+		}
+		if !config.PasswordRules.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password_rules"),
+				"Unexpected password rules",
+				"password_rules can only be set when secret_strategy is 'random'.",
+			)
+		}
+	}
+
+	if config.AutoPush.ValueBool() && !config.Platforms.IsUnknown() && len(config.Platforms.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("platforms"),
+			"Missing platforms",
+			"auto_push needs at least one platform to push the account to.",
+		)
+	}
+
+	resp.Diagnostics.Append(validateSSHKeySecret(config.SecretType, config.Secret, config.Passphrase, "template")...)
+}
+
+func (r *AccountTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AccountTemplateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	createReq, diags := expandAccountTemplate(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(accountTemplateSecret(ctx, req.Config, createReq)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := r.client.CreateAccountTemplate(ctx, createReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error creating account template",
+			fmt.Sprintf("Could not create account template: %s", err),
+			err,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(setAccountTemplateState(ctx, template, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created account template", map[string]any{"id": plan.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AccountTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AccountTemplateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	template, err := r.client.GetAccountTemplate(ctx, state.ID.ValueString())
+	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "account template no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading account template",
+			fmt.Sprintf("Could not read account template: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(setAccountTemplateState(ctx, template, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AccountTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state AccountTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	updateReq, diags := expandAccountTemplate(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	// Rotate the secret when secret_version changes, and send it along with a new
+	// secret_strategy so that switching to 'specific' uses the configured secret
+	if !plan.SecretVersion.Equal(state.SecretVersion) || !plan.SecretStrategy.Equal(state.SecretStrategy) {
+		resp.Diagnostics.Append(accountTemplateSecret(ctx, req.Config, updateReq)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := r.client.UpdateAccountTemplate(ctx, plan.ID.ValueString(), updateReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error updating account template",
+			fmt.Sprintf("Could not update account template: %s", err),
+			err,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(setAccountTemplateState(ctx, template, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AccountTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AccountTemplateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	err := r.client.DeleteAccountTemplate(ctx, state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting account template",
+			fmt.Sprintf("Could not delete account template: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted account template", map[string]any{"id": state.ID.ValueString()})
+}
+
+func (r *AccountTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithOrgID(ctx, req, resp)
+}
+
+// expandAccountTemplate builds the API request from the plan, without the write-only secret
+func expandAccountTemplate(ctx context.Context, plan *AccountTemplateResourceModel) (*jumpserver.AccountTemplateRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	req := &jumpserver.AccountTemplateRequest{
+		Name:           plan.Name.ValueString(),
+		Username:       plan.Username.ValueString(),
+		SecretType:     plan.SecretType.ValueString(),
+		SecretStrategy: plan.SecretStrategy.ValueString(),
+		Privileged:     plan.Privileged.ValueBool(),
+		SuFrom:         plan.SuFrom.ValueStringPointer(),
+		AutoPush:       plan.AutoPush.ValueBool(),
+		Platforms:      []int64{},
+		IsActive:       plan.IsActive.ValueBool(),
+		Comment:        plan.Comment.ValueString(),
+	}
+
+	if !plan.Platforms.IsNull() && !plan.Platforms.IsUnknown() {
+		diags.Append(plan.Platforms.ElementsAs(ctx, &req.Platforms, false)...)
+	}
+
+	if !plan.PasswordRules.IsNull() && !plan.PasswordRules.IsUnknown() {
+		var rules PasswordRulesModel
+		diags.Append(plan.PasswordRules.As(ctx, &rules, basetypes.ObjectAsOptions{})...)
+		req.PasswordRules = &jumpserver.PasswordRules{
+			Length:         int(rules.Length.ValueInt64()),
+			Lowercase:      rules.Lowercase.ValueBool(),
+			Uppercase:      rules.Uppercase.ValueBool(),
+			Digit:          rules.Digit.ValueBool(),
+			Symbol:         rules.Symbol.ValueBool(),
+			ExcludeSymbols: rules.ExcludeSymbols.ValueString(),
+		}
+	}
+
+	return req, diags
+}
+
+// accountTemplateSecret adds the write-only secret and passphrase from the configuration to the request
+func accountTemplateSecret(ctx context.Context, config tfsdk.Config, req *jumpserver.AccountTemplateRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var secret, passphrase types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("secret"), &secret)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("passphrase"), &passphrase)...)
+	req.Secret = secret.ValueString()
+	req.Passphrase = passphrase.ValueString()
+	return diags
+}
+
+// setAccountTemplateState maps an account template returned by the API onto the resource
+// model. Optional attributes the configuration leaves out stay null when the API returns
+// their empty value.
+func setAccountTemplateState(ctx context.Context, template *jumpserver.AccountTemplate, model *AccountTemplateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(template.ID)
+	model.Name = types.StringValue(template.Name)
+	model.Username = types.StringValue(template.Username)
+	model.SecretType = types.StringValue(template.GetSecretTypeValue())
+	model.SecretStrategy = types.StringValue(template.GetSecretStrategyValue())
+	model.Privileged = types.BoolValue(template.Privileged)
+	model.AutoPush = types.BoolValue(template.AutoPush)
+	model.IsActive = types.BoolValue(template.IsActive)
+
+	if suFrom := template.GetSuFromID(); suFrom != "" {
+		model.SuFrom = types.StringValue(suFrom)
+	} else {
+		model.SuFrom = types.StringNull()
+	}
+
+	if template.Comment != "" || !model.Comment.IsNull() {
+		model.Comment = types.StringValue(template.Comment)
+	}
+
+	if platforms := template.GetPlatformIDs(); len(platforms) > 0 || !model.Platforms.IsNull() {
+		var d diag.Diagnostics
+		model.Platforms, d = types.SetValueFrom(ctx, types.Int64Type, platforms)
+		diags.Append(d...)
+	}
+
+	// The server fills in default rules for every template; only track them when configured
+	if !model.PasswordRules.IsNull() && template.PasswordRules != nil {
+		var d diag.Diagnostics
+		model.PasswordRules, d = types.ObjectValueFrom(ctx, passwordRulesAttrTypes, PasswordRulesModel{
+			Length:         types.Int64Value(int64(template.PasswordRules.Length)),
+			Lowercase:      types.BoolValue(template.PasswordRules.Lowercase),
+			Uppercase:      types.BoolValue(template.PasswordRules.Uppercase),
+			Digit:          types.BoolValue(template.PasswordRules.Digit),
+			Symbol:         types.BoolValue(template.PasswordRules.Symbol),
+			ExcludeSymbols: types.StringValue(template.PasswordRules.ExcludeSymbols),
+		})
+		diags.Append(d...)
+	}
+
+	return diags
+}
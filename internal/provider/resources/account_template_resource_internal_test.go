@@ -0,0 +1,242 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestAccountTemplateValidateConfig(t *testing.T) {
+	key, _ := testSSHKey(t)
+
+	rulesType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"length":          tftypes.Number,
+		"lowercase":       tftypes.Bool,
+		"uppercase":       tftypes.Bool,
+		"digit":           tftypes.Bool,
+		"symbol":          tftypes.Bool,
+		"exclude_symbols": tftypes.String,
+	}}
+	rules := tftypes.NewValue(rulesType, map[string]tftypes.Value{
+		"length":          tftypes.NewValue(tftypes.Number, 24),
+		"lowercase":       tftypes.NewValue(tftypes.Bool, nil),
+		"uppercase":       tftypes.NewValue(tftypes.Bool, nil),
+		"digit":           tftypes.NewValue(tftypes.Bool, nil),
+		"symbol":          tftypes.NewValue(tftypes.Bool, nil),
+		"exclude_symbols": tftypes.NewValue(tftypes.String, nil),
+	})
+	random := tftypes.NewValue(tftypes.String, jumpserver.SecretStrategyRandom)
+	secret := tftypes.NewValue(tftypes.String, "hunter2")
+
+	tests := []struct {
+		name   string
+		values map[string]tftypes.Value
+		// wantAttr is the attribute the error is reported on, empty when there is none
+		wantAttr string
+	}{
+		{"specific", map[string]tftypes.Value{"secret": secret}, ""},
+		{"specific without secret", map[string]tftypes.Value{}, "secret"},
+		{"unknown secret", map[string]tftypes.Value{
+			"secret": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}, ""},
+		{"password rules on specific", map[string]tftypes.Value{
+			"secret":         secret,
+			"password_rules": rules,
+		}, "password_rules"},
+		{"random", map[string]tftypes.Value{
+			"secret_strategy": random,
+			"password_rules":  rules,
+		}, ""},
+		{"random with secret", map[string]tftypes.Value{
+			"secret_strategy": random,
+			"secret":          secret,
+		}, "secret"},
+		{"random with secret_version", map[string]tftypes.Value{
+			"secret_strategy": random,
+			"secret_version":  tftypes.NewValue(tftypes.Number, 2),
+		}, "secret_version"},
+		{"auto_push without platforms", map[string]tftypes.Value{
+			"secret":    secret,
+			"auto_push": tftypes.NewValue(tftypes.Bool, true),
+		}, "platforms"},
+		{"ssh key", map[string]tftypes.Value{
+			"secret":      tftypes.NewValue(tftypes.String, key),
+			"secret_type": tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, ""},
+		{"invalid ssh key", map[string]tftypes.Value{
+			"secret":      secret,
+			"secret_type": tftypes.NewValue(tftypes.String, "ssh_key"),
+		}, "secret"},
+		{"passphrase on password", map[string]tftypes.Value{
+			"secret":     secret,
+			"passphrase": tftypes.NewValue(tftypes.String, "s3cret"),
+		}, "passphrase"},
+	}
+
+	for _, tt := range tests {
+		values := map[string]tftypes.Value{
+			"name":     tftypes.NewValue(tftypes.String, "deploy"),
+			"username": tftypes.NewValue(tftypes.String, "deploy"),
+		}
+		for name, value := range tt.values {
+			values[name] = value
+		}
+		config := resourceConfig(t, &AccountTemplateResource{}, values)
+
+		resp := &resource.ValidateConfigResponse{}
+		(&AccountTemplateResource{}).ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+		errs := resp.Diagnostics.Errors()
+		switch {
+		case tt.wantAttr == "" && len(errs) > 0:
+			t.Errorf("%s: unexpected errors %v", tt.name, errs)
+		case tt.wantAttr != "" && len(errs) != 1:
+			t.Errorf("%s: expected one error on %s, got %v", tt.name, tt.wantAttr, errs)
+		case tt.wantAttr != "" && !errs[0].(diag.DiagnosticWithPath).Path().Equal(path.Root(tt.wantAttr)):
+			t.Errorf("%s: expected the error on %s, got %s", tt.name, tt.wantAttr, errs[0].(diag.DiagnosticWithPath).Path())
+		}
+	}
+}
+
+func TestSetAccountTemplateStateKeepsUnsetAttributesNull(t *testing.T) {
+	ctx := context.Background()
+
+	template := &jumpserver.AccountTemplate{
+		ID:             "tpl1",
+		Name:           "deploy",
+		Username:       "deploy",
+		SecretType:     map[string]interface{}{"value": "password", "label": "Password"},
+		SecretStrategy: map[string]interface{}{"value": "specific", "label": "Specific"},
+		PasswordRules:  &jumpserver.PasswordRules{Length: 16, Lowercase: true, Uppercase: true, Digit: true, Symbol: true},
+		Platforms:      []interface{}{},
+		IsActive:       true,
+	}
+	model := AccountTemplateResourceModel{
+		Platforms:     types.SetNull(types.Int64Type),
+		PasswordRules: types.ObjectNull(passwordRulesAttrTypes),
+		SuFrom:        types.StringNull(),
+		Comment:       types.StringNull(),
+	}
+
+	if diags := setAccountTemplateState(ctx, template, &model); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !model.Platforms.IsNull() || !model.PasswordRules.IsNull() || !model.SuFrom.IsNull() || !model.Comment.IsNull() {
+		t.Errorf("expected unset attributes to stay null, got %+v", model)
+	}
+	if model.SecretType.ValueString() != "password" || model.SecretStrategy.ValueString() != jumpserver.SecretStrategySpecific {
+		t.Errorf("unexpected secret settings %s/%s", model.SecretType, model.SecretStrategy)
+	}
+}
+
+func TestSetAccountTemplateStateTracksConfiguredAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	template := &jumpserver.AccountTemplate{
+		ID:             "tpl1",
+		SecretType:     "password",
+		SecretStrategy: "random",
+		PasswordRules:  &jumpserver.PasswordRules{Length: 24, Lowercase: true, Uppercase: true, Digit: true, ExcludeSymbols: "$"},
+		SuFrom:         map[string]interface{}{"id": "tpl0", "name": "root"},
+		Platforms:      []interface{}{map[string]interface{}{"id": float64(1), "name": "Linux"}, float64(2)},
+	}
+	rules, _ := types.ObjectValueFrom(ctx, passwordRulesAttrTypes, PasswordRulesModel{
+		Length:         types.Int64Value(16),
+		Lowercase:      types.BoolValue(true),
+		Uppercase:      types.BoolValue(true),
+		Digit:          types.BoolValue(true),
+		Symbol:         types.BoolValue(true),
+		ExcludeSymbols: types.StringValue(""),
+	})
+	model := AccountTemplateResourceModel{
+		Platforms:     types.SetValueMust(types.Int64Type, nil),
+		PasswordRules: rules,
+		SuFrom:        types.StringNull(),
+		Comment:       types.StringValue("old"),
+	}
+
+	if diags := setAccountTemplateState(ctx, template, &model); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	wantPlatforms := types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(1), types.Int64Value(2)})
+	if !model.Platforms.Equal(wantPlatforms) {
+		t.Errorf("expected platforms %s, got %s", wantPlatforms, model.Platforms)
+	}
+	if model.SuFrom.ValueString() != "tpl0" {
+		t.Errorf("expected su_from tpl0, got %s", model.SuFrom)
+	}
+	if model.Comment.IsNull() || model.Comment.ValueString() != "" {
+		t.Errorf("expected the removed comment to be tracked as empty, got %s", model.Comment)
+	}
+
+	var got PasswordRulesModel
+	model.PasswordRules.As(ctx, &got, basetypes.ObjectAsOptions{})
+	if got.Length.ValueInt64() != 24 || got.Symbol.ValueBool() || got.ExcludeSymbols.ValueString() != "$" {
+		t.Errorf("expected the password rules of the server, got %+v", got)
+	}
+}
+
+func TestAccountTemplateUpdateSendsSecretWithNewStrategy(t *testing.T) {
+	ctx := context.Background()
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"id":"tpl1","name":"deploy","username":"deploy","secret_type":{"value":"password"},"secret_strategy":{"value":"specific"},"is_active":true}`))
+	}))
+	defer server.Close()
+
+	r := &AccountTemplateResource{client: jumpserver.NewClient(&jumpserver.Config{Endpoint: server.URL})}
+
+	tests := []struct {
+		name          string
+		priorStrategy string
+		wantSecret    interface{}
+	}{
+		{"random to specific", jumpserver.SecretStrategyRandom, "s3cret"},
+		{"unchanged", jumpserver.SecretStrategySpecific, nil},
+	}
+
+	for _, tt := range tests {
+		values := map[string]tftypes.Value{
+			"id":              tftypes.NewValue(tftypes.String, "tpl1"),
+			"name":            tftypes.NewValue(tftypes.String, "deploy"),
+			"username":        tftypes.NewValue(tftypes.String, "deploy"),
+			"secret_type":     tftypes.NewValue(tftypes.String, "password"),
+			"secret_strategy": tftypes.NewValue(tftypes.String, jumpserver.SecretStrategySpecific),
+		}
+		planRaw, templateSchema := resourceValue(t, r, values)
+		values["secret"] = tftypes.NewValue(tftypes.String, "s3cret")
+		configRaw, _ := resourceValue(t, r, values)
+		delete(values, "secret")
+		values["secret_strategy"] = tftypes.NewValue(tftypes.String, tt.priorStrategy)
+		stateRaw, _ := resourceValue(t, r, values)
+
+		req := resource.UpdateRequest{
+			Config: tfsdk.Config{Schema: templateSchema, Raw: configRaw},
+			Plan:   tfsdk.Plan{Schema: templateSchema, Raw: planRaw},
+			State:  tfsdk.State{Schema: templateSchema, Raw: stateRaw},
+		}
+		resp := &resource.UpdateResponse{State: tfsdk.State{Schema: templateSchema}}
+		body = nil
+		r.Update(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error %v", tt.name, resp.Diagnostics)
+		}
+		if body["secret"] != tt.wantSecret {
+			t.Errorf("%s: expected secret %v, got %v", tt.name, tt.wantSecret, body["secret"])
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"jumpserver/internal/jumpserver"
+)
+
+// AssetAccountModel describes an account created together with an asset.
+type AssetAccountModel struct {
+	Name          types.String `tfsdk:"name"`
+	Username      types.String `tfsdk:"username"`
+	Secret        types.String `tfsdk:"secret"`
+	SecretVersion types.Int64  `tfsdk:"secret_version"`
+	SecretType    types.String `tfsdk:"secret_type"`
+	Privileged    types.Bool   `tfsdk:"privileged"`
+	PushNow       types.Bool   `tfsdk:"push_now"`
+	SecretReset   types.Bool   `tfsdk:"secret_reset"`
+	OnInvalid     types.String `tfsdk:"on_invalid"`
+	IsActive      types.Bool   `tfsdk:"is_active"`
+	Template      types.String `tfsdk:"template"`
+}
+
+var assetAccountAttrTypes = map[string]attr.Type{
+	"name":           types.StringType,
+	"username":       types.StringType,
+	"secret":         types.StringType,
+	"secret_version": types.Int64Type,
+	"secret_type":    types.StringType,
+	"privileged":     types.BoolType,
+	"push_now":       types.BoolType,
+	"secret_reset":   types.BoolType,
+	"on_invalid":     types.StringType,
+	"is_active":      types.BoolType,
+	"template":       types.StringType,
+}
+
+// assetAccountsFromPlan reads the planned inline accounts. Secrets are write-only and
+// never part of the plan, so they are taken from the configuration instead.
+func assetAccountsFromPlan(ctx context.Context, plan types.List, config tfsdk.Config) ([]AssetAccountModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if plan.IsNull() || plan.IsUnknown() {
+		return nil, diags
+	}
+
+	var accounts []AssetAccountModel
+	diags.Append(plan.ElementsAs(ctx, &accounts, false)...)
+
+	var configAccounts []AssetAccountModel
+	diags.Append(config.GetAttribute(ctx, path.Root("accounts"), &configAccounts)...)
+
+	secrets := make(map[string]types.String, len(configAccounts))
+	for _, a := range configAccounts {
+		secrets[a.Name.ValueString()] = a.Secret
+	}
+	for i := range accounts {
+		accounts[i].Secret = secrets[accounts[i].Name.ValueString()]
+	}
+
+	return accounts, diags
+}
+
+// expandAssetAccounts converts inline accounts into the asset create request format
+func expandAssetAccounts(accounts []AssetAccountModel) []jumpserver.AssetAccount {
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	result := make([]jumpserver.AssetAccount, 0, len(accounts))
+	for _, a := range accounts {
+		result = append(result, jumpserver.AssetAccount{
+			Name:        a.Name.ValueString(),
+			Username:    a.Username.ValueString(),
+			Secret:      a.Secret.ValueString(),
+			SecretType:  a.SecretType.ValueString(),
+			Privileged:  a.Privileged.ValueBool(),
+			PushNow:     a.PushNow.ValueBool(),
+			SecretReset: a.SecretReset.ValueBool(),
+			OnInvalid:   a.OnInvalid.ValueString(),
+			IsActive:    a.IsActive.ValueBool(),
+			Template:    a.Template.ValueString(),
+		})
+	}
+	return result
+}
+
+// assetAccountsToList converts inline accounts into a list value for state, dropping secrets
+func assetAccountsToList(ctx context.Context, accounts []AssetAccountModel) (types.List, diag.Diagnostics) {
+	for i := range accounts {
+		accounts[i].Secret = types.StringNull()
+	}
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: assetAccountAttrTypes}, accounts)
+}
+
+// refreshAssetAccounts updates the inline accounts tracked in state from the accounts
+// that exist on the asset. Accounts deleted outside Terraform are dropped so they are
+// planned for re-creation; accounts never declared inline are ignored.
+func (r *AssetResource) refreshAssetAccounts(ctx context.Context, assetID string, current types.List) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if current.IsNull() || current.IsUnknown() {
+		return current, diags
+	}
+
+	var tracked []AssetAccountModel
+	diags.Append(current.ElementsAs(ctx, &tracked, false)...)
+	if diags.HasError() {
+		return current, diags
+	}
+
+	existing, err := r.client.ListAccounts(ctx, assetID)
+	if err != nil {
+		diags.AddError(
+			"Error reading asset accounts",
+			fmt.Sprintf("Could not list accounts of asset ID %s: %s", assetID, err),
+		)
+		return current, diags
+	}
+
+	byName := make(map[string]jumpserver.Account, len(existing))
+	for _, a := range existing {
+		byName[a.AccountName] = a
+	}
+
+	refreshed := make([]AssetAccountModel, 0, len(tracked))
+	for _, a := range tracked {
+		account, ok := byName[a.Name.ValueString()]
+		if !ok {
+			continue
+		}
+
+		a.Username = types.StringValue(account.Name)
+		a.SecretType = types.StringValue(account.GetSecretTypeValue())
+		a.Privileged = types.BoolValue(account.Privileged)
+		a.IsActive = types.BoolValue(account.IsActive)
+		refreshed = append(refreshed, a)
+	}
+
+	list, d := assetAccountsToList(ctx, refreshed)
+	diags.Append(d...)
+	return list, diags
+}
+
+// reconcileAssetAccounts applies changes to the inline accounts of an existing asset
+// through the accounts API: new accounts are created, removed ones deleted and changed
+// ones updated. Accounts whose template changes are re-created, since a template only
+// applies on creation. Secrets are sent when an account is created, when its
+// secret_version changes or when its secret type changes; ModifyPlan makes sure a
+// secret is configured for the latter two.
+func (r *AssetResource) reconcileAssetAccounts(ctx context.Context, assetID string, prior types.List, planned []AssetAccountModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var previous []AssetAccountModel
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags.Append(prior.ElementsAs(ctx, &previous, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if len(previous) == 0 && len(planned) == 0 {
+		return diags
+	}
+
+	existing, err := r.client.ListAccounts(ctx, assetID)
+	if err != nil {
+		diags.AddError(
+			"Error reading asset accounts",
+			fmt.Sprintf("Could not list accounts of asset ID %s: %s", assetID, err),
+		)
+		return diags
+	}
+
+	existingByName := make(map[string]jumpserver.Account, len(existing))
+	for _, a := range existing {
+		existingByName[a.AccountName] = a
+	}
+
+	plannedByName := make(map[string]AssetAccountModel, len(planned))
+	for _, a := range planned {
+		plannedByName[a.Name.ValueString()] = a
+	}
+
+	previousByName := make(map[string]AssetAccountModel, len(previous))
+	for _, a := range previous {
+		previousByName[a.Name.ValueString()] = a
+	}
+
+	for _, a := range previous {
+		name := a.Name.ValueString()
+		account, ok := existingByName[name]
+		if _, keep := plannedByName[name]; keep || !ok {
+			continue
+		}
+
+		if err := r.client.DeleteAccount(ctx, account.ID); err != nil && !jumpserver.IsNotFound(err) {
+			diags.AddError(
+				"Error deleting asset account",
+				fmt.Sprintf("Could not delete account %s of asset ID %s: %s", name, assetID, err),
+			)
+		}
+	}
+
+	for _, a := range planned {
+		name := a.Name.ValueString()
+		account, ok := existingByName[name]
+		prev, tracked := previousByName[name]
+
+		// Account names are unique per asset, so the old account has to go before the
+		// replacement can be created
+		replaced := ok && tracked && !prev.Template.Equal(a.Template)
+		if replaced {
+			if err := r.client.DeleteAccount(ctx, account.ID); err != nil && !jumpserver.IsNotFound(err) {
+				diags.AddError(
+					"Error replacing asset account",
+					fmt.Sprintf("Could not delete account %s of asset ID %s to apply its new template: %s", name, assetID, err),
+				)
+				continue
+			}
+			ok = false
+		}
+
+		if !ok {
+			_, err := r.client.CreateAccount(ctx, &jumpserver.CreateAccountRequest{
+				Name:        a.Username.ValueString(),
+				AccountName: name,
+				Asset:       assetID,
+				Secret:      a.Secret.ValueString(),
+				SecretType:  a.SecretType.ValueString(),
+				Privileged:  boolPointer(a.Privileged),
+				IsActive:    boolPointer(a.IsActive),
+				PushNow:     a.PushNow.ValueBool(),
+				SecretReset: boolPointer(a.SecretReset),
+				OnInvalid:   a.OnInvalid.ValueString(),
+				Template:    a.Template.ValueString(),
+			})
+			if err != nil && replaced {
+				diags.AddError(
+					"Error replacing asset account",
+					fmt.Sprintf("Account %s of asset ID %s was deleted to apply its new template, but re-creating it failed: %s. Apply again to create it.", name, assetID, err),
+				)
+			} else if err != nil {
+				diags.AddError(
+					"Error creating asset account",
+					fmt.Sprintf("Could not create account %s on asset ID %s: %s", name, assetID, err),
+				)
+			}
+			continue
+		}
+
+		rotate := tracked && !prev.SecretVersion.Equal(a.SecretVersion)
+		// A different secret type needs a matching secret
+		retype := account.GetSecretTypeValue() != a.SecretType.ValueString()
+		// The push and conflict settings are not returned by the API, so they are
+		// compared with the prior state
+		settingsChanged := tracked && (!prev.PushNow.Equal(a.PushNow) ||
+			!prev.SecretReset.Equal(a.SecretReset) ||
+			!prev.OnInvalid.Equal(a.OnInvalid))
+
+		if !rotate && !retype && !settingsChanged &&
+			account.Name == a.Username.ValueString() &&
+			account.Privileged == a.Privileged.ValueBool() &&
+			account.IsActive == a.IsActive.ValueBool() {
+			continue
+		}
+
+		update := &jumpserver.UpdateAccountRequest{
+			Name:        a.Username.ValueString(),
+			SecretType:  a.SecretType.ValueString(),
+			Privileged:  boolPointer(a.Privileged),
+			IsActive:    boolPointer(a.IsActive),
+			PushNow:     a.PushNow.ValueBool(),
+			SecretReset: boolPointer(a.SecretReset),
+			OnInvalid:   a.OnInvalid.ValueString(),
+		}
+		if rotate || retype {
+			update.Secret = a.Secret.ValueString()
+		}
+
+		_, err := r.client.UpdateAccount(ctx, account.ID, update)
+		if err != nil {
+			diags.AddError(
+				"Error updating asset account",
+				fmt.Sprintf("Could not update account %s on asset ID %s: %s", name, assetID, err),
+			)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,224 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"jumpserver/internal/jumpserver"
+)
+
+// assetAccountsServer serves the accounts of an asset and records every change made to them
+func assetAccountsServer(t *testing.T, accounts string) (*httptest.Server, *[]string, map[string]map[string]interface{}) {
+	t.Helper()
+
+	var changes []string
+	bodies := make(map[string]map[string]interface{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(accounts))
+			return
+		}
+
+		change := r.Method + " " + r.URL.Path
+		changes = append(changes, change)
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies[change] = body
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"id":"acc3"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &changes, bodies
+}
+
+// testAssetAccount returns an inline account with the schema defaults
+func testAssetAccount(name string) AssetAccountModel {
+	return AssetAccountModel{
+		Name:          types.StringValue(name),
+		Username:      types.StringValue(name),
+		Secret:        types.StringNull(),
+		SecretVersion: types.Int64Null(),
+		SecretType:    types.StringValue("password"),
+		Privileged:    types.BoolValue(false),
+		PushNow:       types.BoolValue(false),
+		SecretReset:   types.BoolValue(true),
+		OnInvalid:     types.StringValue("error"),
+		IsActive:      types.BoolValue(true),
+		Template:      types.StringNull(),
+	}
+}
+
+func TestReconcileAssetAccountsAppliesEveryChange(t *testing.T) {
+	ctx := context.Background()
+	server, changes, bodies := assetAccountsServer(t, `[
+		{"id":"acc1","name":"root","username":"root","secret_type":"password","is_active":true},
+		{"id":"acc2","name":"deploy","username":"deploy","secret_type":"password","is_active":true},
+		{"id":"acc4","name":"backup","username":"backup","secret_type":"password","is_active":true}
+	]`)
+	r := &AssetResource{client: jumpserver.NewClient(&jumpserver.Config{Endpoint: server.URL})}
+
+	root, deploy, backup := testAssetAccount("root"), testAssetAccount("deploy"), testAssetAccount("backup")
+	root.SecretVersion = types.Int64Value(1)
+	prior, diags := assetAccountsToList(ctx, []AssetAccountModel{root, deploy, backup})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// Rotate the root secret, move deploy to a template and push backup
+	root.SecretVersion = types.Int64Value(2)
+	root.Secret = types.StringValue("n3w")
+	deploy.Template = types.StringValue("tpl1")
+	backup.PushNow = types.BoolValue(true)
+
+	if diags := r.reconcileAssetAccounts(ctx, "a1", prior, []AssetAccountModel{root, deploy, backup}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	want := []string{
+		"PUT /api/v1/accounts/accounts/acc1/",
+		"DELETE /api/v1/accounts/accounts/acc2/",
+		"POST /api/v1/accounts/accounts/",
+		"PUT /api/v1/accounts/accounts/acc4/",
+	}
+	if len(*changes) != len(want) {
+		t.Fatalf("expected changes %v, got %v", want, *changes)
+	}
+	for i := range want {
+		if (*changes)[i] != want[i] {
+			t.Errorf("change %d: expected %s, got %s", i, want[i], (*changes)[i])
+		}
+	}
+
+	if secret := bodies[want[0]]["secret"]; secret != "n3w" {
+		t.Errorf("expected the rotated secret to be sent, got %v", secret)
+	}
+	if template := bodies[want[2]]["template"]; template != "tpl1" {
+		t.Errorf("expected the account to be re-created from tpl1, got %v", template)
+	}
+	if push, secret := bodies[want[3]]["push_now"], bodies[want[3]]["secret"]; push != true || secret != nil {
+		t.Errorf("expected push_now without a secret, got push_now %v and secret %v", push, secret)
+	}
+}
+
+func TestReconcileAssetAccountsReportsFailedReplacement(t *testing.T) {
+	ctx := context.Background()
+	var changes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`[{"id":"acc1","name":"deploy","username":"deploy","secret_type":"password","is_active":true}]`))
+		case http.MethodDelete:
+			changes = append(changes, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			changes = append(changes, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"template":["Invalid pk"]}`))
+		}
+	}))
+	defer server.Close()
+	r := &AssetResource{client: jumpserver.NewClient(&jumpserver.Config{Endpoint: server.URL})}
+
+	deploy := testAssetAccount("deploy")
+	prior, diags := assetAccountsToList(ctx, []AssetAccountModel{deploy})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	deploy.Template = types.StringValue("missing")
+
+	diags = r.reconcileAssetAccounts(ctx, "a1", prior, []AssetAccountModel{deploy})
+	errs := diags.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "Error replacing asset account" {
+		t.Fatalf("expected a replacement error, got %v", diags)
+	}
+	if !strings.Contains(errs[0].Detail(), "was deleted") {
+		t.Errorf("expected the error to say the account was deleted, got %q", errs[0].Detail())
+	}
+	if len(changes) != 2 || changes[0] != "DELETE /api/v1/accounts/accounts/acc1/" {
+		t.Errorf("expected the account to be deleted before re-creating it, got %v", changes)
+	}
+}
+
+// assetAccountsValue builds an asset object with the given inline accounts
+func assetAccountsValue(t *testing.T, accounts []AssetAccountModel) (tftypes.Value, schema.Schema) {
+	t.Helper()
+	ctx := context.Background()
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: assetAccountAttrTypes}, accounts)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	value, err := list.ToTerraformValue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resourceValue(t, &AssetResource{}, map[string]tftypes.Value{"accounts": value})
+}
+
+func TestAssetModifyPlanRejectsChangesWithoutSecret(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		change   func(a *AssetAccountModel)
+		wantAttr string
+	}{
+		{"rotation", func(a *AssetAccountModel) { a.SecretVersion = types.Int64Value(1) }, "secret_version"},
+		{"new secret type", func(a *AssetAccountModel) { a.SecretType = types.StringValue("ssh_key") }, "secret_type"},
+		{"rotation with secret", func(a *AssetAccountModel) {
+			a.SecretVersion = types.Int64Value(1)
+			a.Secret = types.StringValue("n3w")
+		}, ""},
+		{"new template", func(a *AssetAccountModel) {
+			a.SecretType = types.StringValue("ssh_key")
+			a.Template = types.StringValue("tpl1")
+		}, ""},
+		{"unchanged", func(a *AssetAccountModel) {}, ""},
+	}
+
+	for _, tt := range tests {
+		stateRaw, assetSchema := assetAccountsValue(t, []AssetAccountModel{testAssetAccount("root")})
+		root := testAssetAccount("root")
+		tt.change(&root)
+		planRaw, _ := assetAccountsValue(t, []AssetAccountModel{root})
+
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: assetSchema, Raw: planRaw},
+			Plan:   tfsdk.Plan{Schema: assetSchema, Raw: planRaw},
+			State:  tfsdk.State{Schema: assetSchema, Raw: stateRaw},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		(&AssetResource{}).ModifyPlan(ctx, req, resp)
+
+		errs := resp.Diagnostics.Errors()
+		if tt.wantAttr == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: unexpected errors %v", tt.name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 {
+			t.Fatalf("%s: expected one error, got %v", tt.name, errs)
+		}
+		want := path.Root("accounts").AtListIndex(0).AtName(tt.wantAttr)
+		if got := errs[0].(diag.DiagnosticWithPath).Path(); !got.Equal(want) {
+			t.Errorf("%s: expected error on %s, got %s", tt.name, want, got)
+		}
+	}
+}
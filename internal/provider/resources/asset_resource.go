@@ -3,12 +3,22 @@ package resources
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,9 +29,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &AssetResource{}
-	_ resource.ResourceWithConfigure   = &AssetResource{}
-	_ resource.ResourceWithImportState = &AssetResource{}
+	_ resource.Resource                   = &AssetResource{}
+	_ resource.ResourceWithConfigure      = &AssetResource{}
+	_ resource.ResourceWithImportState    = &AssetResource{}
+	_ resource.ResourceWithValidateConfig = &AssetResource{}
+	_ resource.ResourceWithModifyPlan     = &AssetResource{}
 )
 
 // NewAssetResource is a helper function to simplify the provider implementation.
@@ -36,13 +48,21 @@ type AssetResource struct {
 
 // AssetResourceModel describes the resource data model.
 type AssetResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Name     types.String `tfsdk:"name"`
-	Address  types.String `tfsdk:"address"`
-	Platform types.String `tfsdk:"platform"`
-	Nodes    types.List   `tfsdk:"nodes"`
-	IsActive types.Bool   `tfsdk:"is_active"`
-	Comment  types.String `tfsdk:"comment"`
+	ID        types.String   `tfsdk:"id"`
+	Name      types.String   `tfsdk:"name"`
+	Address   types.String   `tfsdk:"address"`
+	Platform  types.String   `tfsdk:"platform"`
+	Category  types.String   `tfsdk:"category"`
+	Database  types.Object   `tfsdk:"database"`
+	Web       types.Object   `tfsdk:"web"`
+	Protocols types.Set      `tfsdk:"protocols"`
+	Accounts  types.List     `tfsdk:"accounts"`
+	Labels    types.Set      `tfsdk:"labels"`
+	Nodes     types.List     `tfsdk:"nodes"`
+	IsActive  types.Bool     `tfsdk:"is_active"`
+	Comment   types.String   `tfsdk:"comment"`
+	OrgID     types.String   `tfsdk:"org_id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *AssetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,7 +71,7 @@ func (r *AssetResource) Metadata(ctx context.Context, req resource.MetadataReque
 
 func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages a JumpServer asset (server, device, etc.)",
+		Description: "Manages a JumpServer asset: a host, database, web application, network device, cloud or custom asset",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -70,12 +90,224 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			},
 			"platform": schema.StringAttribute{
 				Required:    true,
-				Description: "The platform ID (e.g., '1' for Linux)",
+				Description: "The platform ID or name (e.g., '1' or 'Linux'). The configured form is kept in state as long as it refers to the asset's platform",
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
-
+			"category": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The asset category: host, database, web, device, cloud or custom. Defaults to host and is read back from JumpServer when not set. Changing it forces a new asset",
+				Validators: []validator.String{
+					stringvalidator.OneOf(jumpserver.AssetCategories...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"database": schema.SingleNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Connection settings for database assets. Required when category is database",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"db_name": schema.StringAttribute{
+						Required:    true,
+						Description: "The default database name to connect to",
+					},
+					"use_ssl": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Whether to connect to the database over SSL/TLS",
+					},
+					"ca_cert": schema.StringAttribute{
+						Optional:    true,
+						Description: "PEM encoded CA certificate used to verify the database server",
+					},
+					"client_cert": schema.StringAttribute{
+						Optional:    true,
+						Description: "PEM encoded client certificate for mutual TLS",
+					},
+					"client_key": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "PEM encoded client private key for mutual TLS",
+					},
+					"allow_invalid_cert": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Whether to skip verification of the database server certificate",
+					},
+				},
+			},
+			"web": schema.SingleNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Autofill settings for web assets. Only valid when category is web",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"autofill": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "How credentials are filled in on the login page: no, basic or script",
+						Validators: []validator.String{
+							stringvalidator.OneOf("no", "basic", "script"),
+						},
+					},
+					"username_selector": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "CSS selector of the username input for basic autofill",
+					},
+					"password_selector": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "CSS selector of the password input for basic autofill",
+					},
+					"submit_selector": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "CSS selector of the submit button for basic autofill",
+					},
+				},
+			},
+			"protocols": schema.SetNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Protocols the asset accepts connections on. Defaults to the protocols of the platform",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The protocol name (e.g., 'ssh', 'rdp', 'sftp', 'vnc', 'mysql')",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"port": schema.Int64Attribute{
+							Required:    true,
+							Description: "The port the protocol listens on",
+							Validators: []validator.Int64{
+								int64validator.Between(0, 65535),
+							},
+						},
+						"sftp_enabled": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Whether SFTP file transfer is enabled over this SSH protocol",
+						},
+						"sftp_home": schema.StringAttribute{
+							Optional:    true,
+							Description: "The SFTP home directory",
+						},
+						"console": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Whether RDP connections attach to the console session",
+						},
+						"security": schema.StringAttribute{
+							Optional:    true,
+							Description: "The RDP security mode: any, rdp, tls or nla",
+							Validators: []validator.String{
+								stringvalidator.OneOf("any", "rdp", "tls", "nla"),
+							},
+						},
+					},
+				},
+			},
+			"accounts": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Accounts created together with the asset. Accounts are matched by name; accounts created outside this list are left alone",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The account name, unique within the asset",
+						},
+						"username": schema.StringAttribute{
+							Required:    true,
+							Description: "The username used to log in to the asset",
+						},
+						"secret": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							WriteOnly:   true,
+							Description: "The password or key of the account. Write-only: it is sent when the account is created or its secret_version or secret_type changes, and never stored in state",
+						},
+						"secret_version": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Change this value to push the current secret to JumpServer",
+						},
+						"secret_type": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("password"),
+							Description: "The secret type (e.g., 'password', 'ssh_key', 'access_key', 'token')",
+							Validators: []validator.String{
+								stringvalidator.OneOf("password", "ssh_key", "access_key", "token", "api_key"),
+							},
+						},
+						"privileged": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Whether the account is privileged (e.g., root or Administrator)",
+						},
+						"push_now": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Whether to push the account to the asset immediately after it is created or updated",
+						},
+						"secret_reset": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+							Description: "Whether the account's secret may be rotated by change-secret automation",
+						},
+						"on_invalid": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("error"),
+							Description: "What to do when an account with the same name already exists: skip, update or error",
+							Validators: []validator.String{
+								stringvalidator.OneOf("skip", "update", "error"),
+							},
+						},
+						"is_active": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+							Description: "Whether the account is active",
+						},
+						"template": schema.StringAttribute{
+							Optional:    true,
+							Description: "ID of an account template to create the account from. Changing it deletes the account and creates it again from the new template",
+						},
+					},
+				},
+			},
+			"labels": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "Labels attached to the asset in name:value form (e.g., 'env:prod'). Labels that do not exist yet are created by JumpServer",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^:]+:.+$`), "must be in name:value form"),
+					),
+				},
+			},
 			"nodes": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
@@ -90,6 +322,15 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:    true,
 				Description: "Additional comments about the asset",
 			},
+			"org_id": orgIDAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -112,6 +353,121 @@ func (r *AssetResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
+func (r *AssetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AssetResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Category.IsUnknown() {
+		return
+	}
+
+	category := jumpserver.AssetCategoryHost
+	if !config.Category.IsNull() {
+		category = config.Category.ValueString()
+	}
+
+	if category == jumpserver.AssetCategoryDatabase && config.Database.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("database"),
+			"Missing database settings",
+			"Assets in the database category require a database block with at least db_name.",
+		)
+	}
+
+	if category != jumpserver.AssetCategoryDatabase && !config.Database.IsNull() && !config.Database.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("database"),
+			"Unexpected database settings",
+			fmt.Sprintf("Database settings only apply to the database category, but category is %q.", category),
+		)
+	}
+
+	if category != jumpserver.AssetCategoryWeb && !config.Web.IsNull() && !config.Web.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("web"),
+			"Unexpected web settings",
+			fmt.Sprintf("Web settings only apply to the web category, but category is %q.", category),
+		)
+	}
+
+	if !config.Accounts.IsNull() && !config.Accounts.IsUnknown() {
+		var accounts []AssetAccountModel
+		resp.Diagnostics.Append(config.Accounts.ElementsAs(ctx, &accounts, false)...)
+
+		seen := make(map[string]bool, len(accounts))
+		for i, a := range accounts {
+			if a.Name.IsUnknown() {
+				continue
+			}
+			name := a.Name.ValueString()
+			if seen[name] {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("accounts").AtListIndex(i).AtName("name"),
+					"Duplicate account name",
+					fmt.Sprintf("The account name %q is used more than once. Inline account names must be unique.", name),
+				)
+			}
+			seen[name] = true
+		}
+	}
+}
+
+// ModifyPlan rejects inline account changes that need a secret when none is set, so
+// they fail during plan instead of after the asset has been updated
+func (r *AssetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var planned, prior types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("accounts"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("accounts"), &prior)...)
+	if resp.Diagnostics.HasError() || prior.IsNull() || prior.IsUnknown() {
+		return
+	}
+
+	accounts, diags := assetAccountsFromPlan(ctx, planned, req.Config)
+	resp.Diagnostics.Append(diags...)
+
+	var previous []AssetAccountModel
+	resp.Diagnostics.Append(prior.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previousByName := make(map[string]AssetAccountModel, len(previous))
+	for _, a := range previous {
+		previousByName[a.Name.ValueString()] = a
+	}
+
+	for i, a := range accounts {
+		prev, tracked := previousByName[a.Name.ValueString()]
+		// Accounts with a new template are re-created, which needs no secret
+		if !tracked || !a.Secret.IsNull() || !prev.Template.Equal(a.Template) {
+			continue
+		}
+
+		if !a.SecretVersion.IsUnknown() && !prev.SecretVersion.Equal(a.SecretVersion) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("accounts").AtListIndex(i).AtName("secret_version"),
+				"Nothing to rotate",
+				fmt.Sprintf("The secret_version of account %s changed but its secret is not set. Set secret to the new value, or rotate template secrets in JumpServer.", a.Name.ValueString()),
+			)
+		}
+		if !a.SecretType.IsUnknown() && !prev.SecretType.Equal(a.SecretType) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("accounts").AtListIndex(i).AtName("secret_type"),
+				"Missing account secret",
+				fmt.Sprintf("The secret_type of account %s changed but its secret is not set. Set secret to a value of the new type.", a.Name.ValueString()),
+			)
+		}
+	}
+}
+
 func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan AssetResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -120,6 +476,22 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	// Assets without a configured category are created as hosts
+	if plan.Category.IsUnknown() {
+		plan.Category = types.StringValue(jumpserver.AssetCategoryHost)
+	}
+
 	// Convert nodes list
 	var nodes []string
 	diags = plan.Nodes.ElementsAs(ctx, &nodes, false)
@@ -128,10 +500,10 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Create asset - parse platform as integer
-	platformID := 1 // default value
-	if plan.Platform.ValueString() != "" {
-		fmt.Sscanf(plan.Platform.ValueString(), "%d", &platformID)
+	platformID, diags := r.resolvePlatform(ctx, plan.Platform.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Convert nodes to NodeRequest format
@@ -140,20 +512,51 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 		nodeReqs = append(nodeReqs, jumpserver.NodeRequest{PK: nodeID})
 	}
 
+	database, web, diags := expandAssetSettings(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	protocols, diags := expandAssetProtocols(ctx, plan.Protocols)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	labels, diags := expandAssetLabels(ctx, plan.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accounts, diags := assetAccountsFromPlan(ctx, plan.Accounts, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createReq := &jumpserver.CreateAssetRequest{
-		Name:     plan.Name.ValueString(),
-		Address:  plan.Address.ValueString(),
-		Platform: jumpserver.PlatformRequest{PK: platformID},
-		Nodes:    nodeReqs,
-		IsActive: plan.IsActive.ValueBool(),
-		Comment:  plan.Comment.ValueString(),
+		Name:             plan.Name.ValueString(),
+		Address:          plan.Address.ValueString(),
+		Platform:         jumpserver.PlatformRequest{PK: platformID},
+		Nodes:            nodeReqs,
+		IsActive:         plan.IsActive.ValueBool(),
+		Comment:          plan.Comment.ValueString(),
+		Protocols:        protocols,
+		Accounts:         expandAssetAccounts(accounts),
+		Labels:           labels,
+		DatabaseSettings: database,
+		WebSettings:      web,
 	}
 
-	asset, err := r.client.CreateAsset(createReq)
+	asset, err := r.client.CreateAsset(ctx, plan.Category.ValueString(), createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
 			"Error creating asset",
 			fmt.Sprintf("Could not create asset, unexpected error: %s", err),
+			err,
 		)
 		return
 	}
@@ -167,8 +570,7 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 	} else {
 		plan.Address = types.StringValue(asset.Address)
 	}
-	// Keep platform as name (not ID) for consistency with schema
-	plan.Platform = types.StringValue(asset.Platform.Name)
+	plan.Platform = platformValue(&asset.Platform, plan.Platform)
 
 	// Convert nodes back to list
 	var nodeIDs []string
@@ -185,6 +587,24 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 	plan.IsActive = types.BoolValue(asset.IsActive)
 	plan.Comment = types.StringValue(asset.Comment)
 
+	diags = flattenAssetSettings(ctx, plan.Category.ValueString(), asset, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Protocols, diags = flattenAssetProtocols(ctx, asset.Protocols, plan.Protocols)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Labels, diags = flattenAssetLabels(ctx, asset)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Log creation
 	tflog.Trace(ctx, "created asset", map[string]any{"id": plan.ID.ValueString()})
 
@@ -204,8 +624,44 @@ func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	asset, err := r.client.GetAsset(state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	// Imported assets have no category yet; look it up through the generic endpoint
+	if state.Category.IsNull() || state.Category.ValueString() == "" {
+		asset, err := r.client.GetAsset(ctx, "", state.ID.ValueString())
+		if err != nil {
+			if jumpserver.IsNotFound(err) {
+				tflog.Warn(ctx, "asset no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+				resp.State.RemoveResource(ctx)
+				return
+			}
+
+			resp.Diagnostics.AddError(
+				"Error reading asset",
+				fmt.Sprintf("Could not read asset ID %s: %s", state.ID.ValueString(), err),
+			)
+			return
+		}
+		state.Category = types.StringValue(asset.GetCategoryValue())
+	}
+
+	asset, err := r.client.GetAsset(ctx, state.Category.ValueString(), state.ID.ValueString())
 	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "asset no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error reading asset",
 			fmt.Sprintf("Could not read asset ID %s: %s", state.ID.ValueString(), err),
@@ -216,14 +672,16 @@ func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Map response body to model
 	state.ID = types.StringValue(asset.ID)
 	state.Name = types.StringValue(asset.Name)
+	if category := asset.GetCategoryValue(); category != "" {
+		state.Category = types.StringValue(category)
+	}
 	// Use Addrs from response if available, otherwise use Address
 	if asset.Addrs != "" {
 		state.Address = types.StringValue(asset.Addrs)
 	} else {
 		state.Address = types.StringValue(asset.Address)
 	}
-	// Keep platform as name (not ID) for consistency with schema
-	state.Platform = types.StringValue(asset.Platform.Name)
+	state.Platform = platformValue(&asset.Platform, state.Platform)
 
 	// Convert nodes to list
 	var nodeIDs []string
@@ -240,6 +698,30 @@ func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.IsActive = types.BoolValue(asset.IsActive)
 	state.Comment = types.StringValue(asset.Comment)
 
+	diags = flattenAssetSettings(ctx, state.Category.ValueString(), asset, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Protocols, diags = flattenAssetProtocols(ctx, asset.Protocols, state.Protocols)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Labels, diags = flattenAssetLabels(ctx, asset)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Accounts, diags = r.refreshAssetAccounts(ctx, state.ID.ValueString(), state.Accounts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set state
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -256,6 +738,17 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
 	// Convert nodes list
 	var nodes []string
 	diags = plan.Nodes.ElementsAs(ctx, &nodes, false)
@@ -264,10 +757,10 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Update asset - parse platform as integer
-	platformID := 1 // default value
-	if plan.Platform.ValueString() != "" {
-		fmt.Sscanf(plan.Platform.ValueString(), "%d", &platformID)
+	platformID, diags := r.resolvePlatform(ctx, plan.Platform.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Convert nodes to NodeRequest format
@@ -276,24 +769,69 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		nodeReqs = append(nodeReqs, jumpserver.NodeRequest{PK: nodeID})
 	}
 
+	database, web, diags := expandAssetSettings(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	protocols, diags := expandAssetProtocols(ctx, plan.Protocols)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	labels, diags := expandAssetLabels(ctx, plan.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accounts, diags := assetAccountsFromPlan(ctx, plan.Accounts, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var priorAccounts types.List
+	diags = req.State.GetAttribute(ctx, path.Root("accounts"), &priorAccounts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	updateReq := &jumpserver.UpdateAssetRequest{
-		Name:     plan.Name.ValueString(),
-		Address:  plan.Address.ValueString(),
-		Platform: jumpserver.PlatformRequest{PK: platformID},
-		Nodes:    nodeReqs,
-		IsActive: &[]bool{plan.IsActive.ValueBool()}[0],
-		Comment:  plan.Comment.ValueString(),
+		Name:             plan.Name.ValueString(),
+		Address:          plan.Address.ValueString(),
+		Platform:         jumpserver.PlatformRequest{PK: platformID},
+		Nodes:            nodeReqs,
+		IsActive:         &[]bool{plan.IsActive.ValueBool()}[0],
+		Comment:          plan.Comment.ValueString(),
+		Protocols:        protocols,
+		DatabaseSettings: database,
+		WebSettings:      web,
+	}
+	if labels != nil {
+		updateReq.Labels = &labels
 	}
 
-	asset, err := r.client.UpdateAsset(plan.ID.ValueString(), updateReq)
+	asset, err := r.client.UpdateAsset(ctx, plan.Category.ValueString(), plan.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
 			"Error updating asset",
 			fmt.Sprintf("Could not update asset ID %s: %s", plan.ID.ValueString(), err),
+			err,
 		)
 		return
 	}
 
+	diags = r.reconcileAssetAccounts(ctx, plan.ID.ValueString(), priorAccounts, accounts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Map response body to model
 	plan.Name = types.StringValue(asset.Name)
 	// Use Addrs from response if available, otherwise use Address
@@ -302,8 +840,7 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 	} else {
 		plan.Address = types.StringValue(asset.Address)
 	}
-	// Keep platform as name (not ID) for consistency with schema
-	plan.Platform = types.StringValue(asset.Platform.Name)
+	plan.Platform = platformValue(&asset.Platform, plan.Platform)
 
 	// Convert nodes back to list
 	var nodeIDs []string
@@ -320,6 +857,24 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 	plan.IsActive = types.BoolValue(asset.IsActive)
 	plan.Comment = types.StringValue(asset.Comment)
 
+	diags = flattenAssetSettings(ctx, plan.Category.ValueString(), asset, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Protocols, diags = flattenAssetProtocols(ctx, asset.Protocols, plan.Protocols)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Labels, diags = flattenAssetLabels(ctx, asset)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -336,8 +891,20 @@ func (r *AssetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteAsset(state.ID.ValueString())
-	if err != nil {
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	err := r.client.DeleteAsset(ctx, state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting asset",
 			fmt.Sprintf("Could not delete asset ID %s: %s", state.ID.ValueString(), err),
@@ -350,5 +917,58 @@ func (r *AssetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *AssetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithOrgID(ctx, req, resp)
+}
+
+// resolvePlatform looks up a platform by ID or name and returns its ID
+func (r *AssetResource) resolvePlatform(ctx context.Context, ref string) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	platforms, err := r.client.ListPlatforms(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error reading platforms",
+			fmt.Sprintf("Could not list platforms to resolve %q: %s", ref, err),
+		)
+		return 0, diags
+	}
+
+	for _, p := range platforms {
+		if !platformMatches(&p, ref) {
+			continue
+		}
+
+		id, err := strconv.Atoi(p.GetID())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("platform"),
+				"Invalid platform ID",
+				fmt.Sprintf("Platform %q has a non-numeric ID %q.", ref, p.GetID()),
+			)
+			return 0, diags
+		}
+		return id, diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("platform"),
+		"Unknown platform",
+		fmt.Sprintf("No platform with ID or name %q exists in JumpServer.", ref),
+	)
+	return 0, diags
+}
+
+// platformMatches reports whether ref is the ID or name of the platform
+func platformMatches(p *jumpserver.Platform, ref string) bool {
+	return ref != "" && (p.GetID() == ref || p.Name == ref)
+}
+
+// platformValue returns the platform for state from the platform embedded in the asset.
+// The prior value is kept while it is the ID or name of that platform, so either form
+// can be configured without causing a diff.
+func platformValue(p *jumpserver.Platform, prior types.String) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && platformMatches(p, prior.ValueString()) {
+		return prior
+	}
+	return types.StringValue(p.Name)
 }
@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestPlatformMatches(t *testing.T) {
+	linux := &jumpserver.Platform{ID: float64(1), Name: "Linux", DisplayName: "Linux (SSH)"}
+
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{"1", true},
+		{"Linux", true},
+		{"linux", false},
+		{"Linux (SSH)", false},
+		{"2", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := platformMatches(linux, tt.ref); got != tt.want {
+			t.Errorf("%q: expected %t, got %t", tt.ref, tt.want, got)
+		}
+	}
+}
+
+func TestPlatformValue(t *testing.T) {
+	linux := &jumpserver.Platform{ID: float64(1), Name: "Linux"}
+
+	tests := []struct {
+		name  string
+		prior types.String
+		want  types.String
+	}{
+		{"by ID", types.StringValue("1"), types.StringValue("1")},
+		{"by name", types.StringValue("Linux"), types.StringValue("Linux")},
+		{"changed out of band", types.StringValue("Windows"), types.StringValue("Linux")},
+		{"imported", types.StringNull(), types.StringValue("Linux")},
+		{"unknown", types.StringUnknown(), types.StringValue("Linux")},
+	}
+
+	for _, tt := range tests {
+		if got := platformValue(linux, tt.prior); !got.Equal(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestResolvePlatform(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/assets/platforms/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":1,"name":"Linux"},{"id":5,"name":"Windows"},{"id":"x","name":"Broken"}]`))
+	}))
+	defer server.Close()
+
+	r := &AssetResource{client: jumpserver.NewClient(&jumpserver.Config{Endpoint: server.URL})}
+
+	tests := []struct {
+		ref       string
+		want      int
+		wantError bool
+	}{
+		{"1", 1, false},
+		{"Windows", 5, false},
+		{"5", 5, false},
+		{"Solaris", 0, true},
+		{"Broken", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, diags := r.resolvePlatform(context.Background(), tt.ref)
+		if diags.HasError() != tt.wantError {
+			t.Errorf("%q: expected error %t, got %v", tt.ref, tt.wantError, diags)
+		}
+		if got != tt.want {
+			t.Errorf("%q: expected platform %d, got %d", tt.ref, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package resources
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"jumpserver/internal/jumpserver"
+)
+
+// AssetDatabaseModel describes the database settings of an asset.
+type AssetDatabaseModel struct {
+	DBName           types.String `tfsdk:"db_name"`
+	UseSSL           types.Bool   `tfsdk:"use_ssl"`
+	CACert           types.String `tfsdk:"ca_cert"`
+	ClientCert       types.String `tfsdk:"client_cert"`
+	ClientKey        types.String `tfsdk:"client_key"`
+	AllowInvalidCert types.Bool   `tfsdk:"allow_invalid_cert"`
+}
+
+var assetDatabaseAttrTypes = map[string]attr.Type{
+	"db_name":            types.StringType,
+	"use_ssl":            types.BoolType,
+	"ca_cert":            types.StringType,
+	"client_cert":        types.StringType,
+	"client_key":         types.StringType,
+	"allow_invalid_cert": types.BoolType,
+}
+
+// AssetWebModel describes the autofill settings of a web asset.
+type AssetWebModel struct {
+	Autofill         types.String `tfsdk:"autofill"`
+	UsernameSelector types.String `tfsdk:"username_selector"`
+	PasswordSelector types.String `tfsdk:"password_selector"`
+	SubmitSelector   types.String `tfsdk:"submit_selector"`
+}
+
+var assetWebAttrTypes = map[string]attr.Type{
+	"autofill":          types.StringType,
+	"username_selector": types.StringType,
+	"password_selector": types.StringType,
+	"submit_selector":   types.StringType,
+}
+
+// boolPointer returns nil for null or unknown values so the API applies its default
+func boolPointer(v types.Bool) *bool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	b := v.ValueBool()
+	return &b
+}
+
+// expandAssetSettings converts the category-specific settings in the plan into request fields
+func expandAssetSettings(ctx context.Context, plan *AssetResourceModel) (*jumpserver.DatabaseSettings, *jumpserver.WebSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var database *jumpserver.DatabaseSettings
+	var web *jumpserver.WebSettings
+
+	if !plan.Database.IsNull() && !plan.Database.IsUnknown() {
+		var model AssetDatabaseModel
+		diags.Append(plan.Database.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+		database = &jumpserver.DatabaseSettings{
+			DBName:           model.DBName.ValueString(),
+			UseSSL:           boolPointer(model.UseSSL),
+			CACert:           model.CACert.ValueString(),
+			ClientCert:       model.ClientCert.ValueString(),
+			ClientKey:        model.ClientKey.ValueString(),
+			AllowInvalidCert: boolPointer(model.AllowInvalidCert),
+		}
+	}
+
+	if !plan.Web.IsNull() && !plan.Web.IsUnknown() {
+		var model AssetWebModel
+		diags.Append(plan.Web.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+		web = &jumpserver.WebSettings{
+			UsernameSelector: model.UsernameSelector.ValueString(),
+			PasswordSelector: model.PasswordSelector.ValueString(),
+			SubmitSelector:   model.SubmitSelector.ValueString(),
+		}
+		if !model.Autofill.IsNull() && !model.Autofill.IsUnknown() {
+			web.Autofill = model.Autofill.ValueString()
+		}
+	}
+
+	return database, web, diags
+}
+
+// flattenAssetSettings maps the category-specific fields of an API asset onto the model.
+// The client key is never taken from the API, so the value already in the model is kept;
+// certificates are taken from the API unless it omits or masks them.
+func flattenAssetSettings(ctx context.Context, category string, asset *jumpserver.Asset, model *AssetResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if category == jumpserver.AssetCategoryDatabase && asset.DatabaseSettings != nil {
+		var prior AssetDatabaseModel
+		if !model.Database.IsNull() && !model.Database.IsUnknown() {
+			diags.Append(model.Database.As(ctx, &prior, basetypes.ObjectAsOptions{})...)
+		}
+
+		settings := asset.DatabaseSettings
+		database := AssetDatabaseModel{
+			DBName:           types.StringValue(settings.DBName),
+			UseSSL:           types.BoolValue(settings.UseSSL != nil && *settings.UseSSL),
+			CACert:           certValueOrPrior(settings.CACert, prior.CACert),
+			ClientCert:       certValueOrPrior(settings.ClientCert, prior.ClientCert),
+			ClientKey:        stringValueOrPrior("", prior.ClientKey),
+			AllowInvalidCert: types.BoolValue(settings.AllowInvalidCert != nil && *settings.AllowInvalidCert),
+		}
+
+		var d diag.Diagnostics
+		model.Database, d = types.ObjectValueFrom(ctx, assetDatabaseAttrTypes, database)
+		diags.Append(d...)
+	} else {
+		model.Database = types.ObjectNull(assetDatabaseAttrTypes)
+	}
+
+	if category == jumpserver.AssetCategoryWeb && asset.WebSettings != nil {
+		settings := asset.WebSettings
+		web := AssetWebModel{
+			Autofill:         types.StringValue(settings.GetAutofillValue()),
+			UsernameSelector: types.StringValue(settings.UsernameSelector),
+			PasswordSelector: types.StringValue(settings.PasswordSelector),
+			SubmitSelector:   types.StringValue(settings.SubmitSelector),
+		}
+
+		var d diag.Diagnostics
+		model.Web, d = types.ObjectValueFrom(ctx, assetWebAttrTypes, web)
+		diags.Append(d...)
+	} else {
+		model.Web = types.ObjectNull(assetWebAttrTypes)
+	}
+
+	return diags
+}
+
+// stringValueOrPrior returns the API value, or the prior value when the API omits it
+func stringValueOrPrior(value string, prior types.String) types.String {
+	if value != "" {
+		return types.StringValue(value)
+	}
+	if prior.IsUnknown() {
+		return types.StringNull()
+	}
+	return prior
+}
+
+// certValueOrPrior returns the certificate from the API, or the prior value when the API
+// omits it or only returns a mask such as "******"
+func certValueOrPrior(value string, prior types.String) types.String {
+	if strings.Trim(value, "*") == "" {
+		value = ""
+	}
+	return stringValueOrPrior(value, prior)
+}
+
+// AssetProtocolModel describes a protocol of an asset.
+type AssetProtocolModel struct {
+	Name        types.String `tfsdk:"name"`
+	Port        types.Int64  `tfsdk:"port"`
+	SFTPEnabled types.Bool   `tfsdk:"sftp_enabled"`
+	SFTPHome    types.String `tfsdk:"sftp_home"`
+	Console     types.Bool   `tfsdk:"console"`
+	Security    types.String `tfsdk:"security"`
+}
+
+var assetProtocolAttrTypes = map[string]attr.Type{
+	"name":         types.StringType,
+	"port":         types.Int64Type,
+	"sftp_enabled": types.BoolType,
+	"sftp_home":    types.StringType,
+	"console":      types.BoolType,
+	"security":     types.StringType,
+}
+
+// expandAssetProtocols converts the planned protocols into request protocols.
+// A null or unknown set returns nil so the platform defaults apply.
+func expandAssetProtocols(ctx context.Context, set types.Set) ([]jumpserver.Protocol, diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() {
+		return nil, nil
+	}
+
+	var models []AssetProtocolModel
+	diags := set.ElementsAs(ctx, &models, false)
+
+	protocols := make([]jumpserver.Protocol, 0, len(models))
+	for _, m := range models {
+		protocol := jumpserver.Protocol{
+			Name: m.Name.ValueString(),
+			Port: int(m.Port.ValueInt64()),
+		}
+
+		setting := jumpserver.ProtocolSetting{
+			SFTPEnabled: boolPointer(m.SFTPEnabled),
+			SFTPHome:    m.SFTPHome.ValueString(),
+			Console:     boolPointer(m.Console),
+			Security:    m.Security.ValueString(),
+		}
+		if setting != (jumpserver.ProtocolSetting{}) {
+			protocol.Setting = &setting
+		}
+
+		protocols = append(protocols, protocol)
+	}
+
+	return protocols, diags
+}
+
+// flattenAssetProtocols converts API protocols into a set. Per-protocol settings are
+// only tracked when they were configured before, since the API fills in defaults for the rest.
+func flattenAssetProtocols(ctx context.Context, protocols []jumpserver.Protocol, prior types.Set) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	priorByName := map[string]AssetProtocolModel{}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var models []AssetProtocolModel
+		diags.Append(prior.ElementsAs(ctx, &models, false)...)
+		for _, m := range models {
+			priorByName[m.Name.ValueString()] = m
+		}
+	}
+
+	models := make([]AssetProtocolModel, 0, len(protocols))
+	for _, p := range protocols {
+		m := AssetProtocolModel{
+			Name:        types.StringValue(p.Name),
+			Port:        types.Int64Value(int64(p.Port)),
+			SFTPEnabled: types.BoolNull(),
+			SFTPHome:    types.StringNull(),
+			Console:     types.BoolNull(),
+			Security:    types.StringNull(),
+		}
+
+		if prev, ok := priorByName[p.Name]; ok {
+			setting := jumpserver.ProtocolSetting{}
+			if p.Setting != nil {
+				setting = *p.Setting
+			}
+			if !prev.SFTPEnabled.IsNull() {
+				m.SFTPEnabled = prev.SFTPEnabled
+				if setting.SFTPEnabled != nil {
+					m.SFTPEnabled = types.BoolValue(*setting.SFTPEnabled)
+				}
+			}
+			if !prev.SFTPHome.IsNull() {
+				m.SFTPHome = stringValueOrPrior(setting.SFTPHome, prev.SFTPHome)
+			}
+			if !prev.Console.IsNull() {
+				m.Console = prev.Console
+				if setting.Console != nil {
+					m.Console = types.BoolValue(*setting.Console)
+				}
+			}
+			if !prev.Security.IsNull() {
+				m.Security = stringValueOrPrior(setting.Security, prev.Security)
+			}
+		}
+
+		models = append(models, m)
+	}
+
+	set, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: assetProtocolAttrTypes}, models)
+	diags.Append(d...)
+	return set, diags
+}
+
+// expandAssetLabels converts the labels set into name:value keys. Unknown labels are
+// left to the server, known labels are always sent so an empty set clears them.
+func expandAssetLabels(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() {
+		return nil, nil
+	}
+
+	labels := []string{}
+	diags := set.ElementsAs(ctx, &labels, false)
+	return labels, diags
+}
+
+// flattenAssetLabels converts the labels of an asset into a set of name:value keys
+func flattenAssetLabels(ctx context.Context, asset *jumpserver.Asset) (types.Set, diag.Diagnostics) {
+	keys := asset.GetLabelKeys()
+	if keys == nil {
+		keys = []string{}
+	}
+	return types.SetValueFrom(ctx, types.StringType, keys)
+}
@@ -0,0 +1,170 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestFlattenAssetSettingsKeepsSecretsFromState(t *testing.T) {
+	ctx := context.Background()
+
+	prior, diags := types.ObjectValueFrom(ctx, assetDatabaseAttrTypes, AssetDatabaseModel{
+		DBName:           types.StringValue("payments"),
+		UseSSL:           types.BoolValue(true),
+		CACert:           types.StringValue("ca"),
+		ClientCert:       types.StringValue("cert"),
+		ClientKey:        types.StringValue("key"),
+		AllowInvalidCert: types.BoolValue(false),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	model := &AssetResourceModel{Database: prior}
+
+	asset := &jumpserver.Asset{DatabaseSettings: &jumpserver.DatabaseSettings{
+		DBName:     "payments",
+		CACert:     "new-ca",
+		ClientCert: "******",
+		ClientKey:  "******",
+	}}
+	if diags := flattenAssetSettings(ctx, jumpserver.AssetCategoryDatabase, asset, model); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var got AssetDatabaseModel
+	model.Database.As(ctx, &got, basetypes.ObjectAsOptions{})
+	if got.CACert.ValueString() != "new-ca" {
+		t.Errorf("expected the CA certificate of the server, got %s", got.CACert)
+	}
+	if got.ClientCert.ValueString() != "cert" {
+		t.Errorf("expected the masked client certificate to keep its prior value, got %s", got.ClientCert)
+	}
+	if got.ClientKey.ValueString() != "key" {
+		t.Errorf("expected the client key to keep its prior value, got %s", got.ClientKey)
+	}
+}
+
+// testAssetProtocols builds a protocols set value from the given models
+func testAssetProtocols(t *testing.T, models ...AssetProtocolModel) types.Set {
+	t.Helper()
+
+	set, diags := types.SetValueFrom(context.Background(), types.ObjectType{AttrTypes: assetProtocolAttrTypes}, models)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	return set
+}
+
+func TestAssetProtocolsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	planned := testAssetProtocols(t,
+		AssetProtocolModel{
+			Name:        types.StringValue("ssh"),
+			Port:        types.Int64Value(2222),
+			SFTPEnabled: types.BoolValue(true),
+			SFTPHome:    types.StringValue("/tmp"),
+			Console:     types.BoolNull(),
+			Security:    types.StringNull(),
+		},
+		AssetProtocolModel{
+			Name:        types.StringValue("rdp"),
+			Port:        types.Int64Value(3389),
+			SFTPEnabled: types.BoolNull(),
+			SFTPHome:    types.StringNull(),
+			Console:     types.BoolValue(true),
+			Security:    types.StringValue("tls"),
+		},
+	)
+
+	protocols, diags := expandAssetProtocols(ctx, planned)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	byName := make(map[string]jumpserver.Protocol, len(protocols))
+	for _, p := range protocols {
+		byName[p.Name] = p
+	}
+	ssh, rdp := byName["ssh"], byName["rdp"]
+	if ssh.Port != 2222 || ssh.Setting == nil || !*ssh.Setting.SFTPEnabled || ssh.Setting.SFTPHome != "/tmp" || ssh.Setting.Console != nil {
+		t.Errorf("unexpected ssh protocol %+v", ssh)
+	}
+	if rdp.Port != 3389 || rdp.Setting == nil || !*rdp.Setting.Console || rdp.Setting.Security != "tls" || rdp.Setting.SFTPEnabled != nil {
+		t.Errorf("unexpected rdp protocol %+v", rdp)
+	}
+
+	// The API fills in defaults for the settings that were not sent
+	disabled := false
+	ssh.Setting.Console = &disabled
+	rdp.Setting.SFTPEnabled = &disabled
+	rdp.Setting.SFTPHome = "/tmp"
+
+	got, diags := flattenAssetProtocols(ctx, []jumpserver.Protocol{ssh, rdp}, planned)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !got.Equal(planned) {
+		t.Errorf("expected %s, got %s", planned, got)
+	}
+}
+
+func TestExpandAssetProtocolsOmitsUnsetSettings(t *testing.T) {
+	ctx := context.Background()
+
+	planned := testAssetProtocols(t, AssetProtocolModel{
+		Name:        types.StringValue("ssh"),
+		Port:        types.Int64Value(22),
+		SFTPEnabled: types.BoolNull(),
+		SFTPHome:    types.StringNull(),
+		Console:     types.BoolNull(),
+		Security:    types.StringNull(),
+	})
+
+	protocols, diags := expandAssetProtocols(ctx, planned)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(protocols) != 1 || protocols[0].Port != 22 || protocols[0].Setting != nil {
+		t.Errorf("expected ssh on port 22 without settings, got %+v", protocols)
+	}
+}
+
+func TestFlattenAssetProtocolsKeepsUnconfiguredSettingsUntracked(t *testing.T) {
+	ctx := context.Background()
+
+	enabled := true
+	protocols := []jumpserver.Protocol{
+		{Name: "ssh", Port: 22, Setting: &jumpserver.ProtocolSetting{SFTPEnabled: &enabled, SFTPHome: "/tmp", Console: &enabled, Security: "any"}},
+	}
+	want := testAssetProtocols(t, AssetProtocolModel{
+		Name:        types.StringValue("ssh"),
+		Port:        types.Int64Value(22),
+		SFTPEnabled: types.BoolNull(),
+		SFTPHome:    types.StringNull(),
+		Console:     types.BoolNull(),
+		Security:    types.StringNull(),
+	})
+
+	tests := []struct {
+		name  string
+		prior types.Set
+	}{
+		{"import", types.SetNull(types.ObjectType{AttrTypes: assetProtocolAttrTypes})},
+		{"port only", want},
+	}
+
+	for _, tt := range tests {
+		got, diags := flattenAssetProtocols(ctx, protocols, tt.prior)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected error: %v", tt.name, diags)
+		}
+		if !got.Equal(want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, want, got)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"jumpserver/internal/jumpserver"
+)
+
+// rfc3339Pattern matches RFC 3339 timestamps such as 2026-12-31T23:59:59Z or 2026-12-31T23:59:59+08:00
+var rfc3339Pattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+
+// timeValue maps a date returned by the API onto a state value in UTC. The prior value
+// is kept when it denotes the same instant, so a date configured in another time zone
+// does not show up as drift.
+func timeValue(apiValue string, prior types.String) (types.String, error) {
+	if apiValue == "" {
+		return types.StringNull(), nil
+	}
+
+	t, err := jumpserver.ParseTime(apiValue)
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if p, err := time.Parse(time.RFC3339, prior.ValueString()); err == nil && p.Equal(t) {
+			return prior, nil
+		}
+	}
+
+	return types.StringValue(t.UTC().Format(time.RFC3339)), nil
+}
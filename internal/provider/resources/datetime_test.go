@@ -0,0 +1,31 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimeValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiValue string
+		prior    types.String
+		want     types.String
+	}{
+		{"empty", "", types.StringValue("2026-01-01T00:00:00Z"), types.StringNull()},
+		{"normalized to UTC", "2026/04/01 00:00:00 +0800", types.StringNull(), types.StringValue("2026-03-31T16:00:00Z")},
+		{"same instant keeps prior", "2026/04/01 00:00:00 +0800", types.StringValue("2026-04-01T00:00:00+08:00"), types.StringValue("2026-04-01T00:00:00+08:00")},
+		{"changed instant", "2026/05/01 00:00:00 +0800", types.StringValue("2026-04-01T00:00:00+08:00"), types.StringValue("2026-04-30T16:00:00Z")},
+	}
+	for _, tt := range tests {
+		got, err := timeValue(tt.apiValue, tt.prior)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"jumpserver/internal/jumpserver"
+)
+
+// attributeSchema is satisfied by the schema attached to a plan, state or config
+type attributeSchema interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// addAPIError reports a client error. JumpServer field errors are attached to the
+// matching schema attribute; anything that cannot be matched is reported as a
+// general error with the given summary and detail.
+func addAPIError(ctx context.Context, diags *diag.Diagnostics, s attributeSchema, summary, detail string, err error) {
+	apiErr, ok := jumpserver.AsAPIError(err)
+	if !ok || !jumpserver.IsValidation(err) || len(apiErr.FieldErrors) == 0 {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	fields := make([]string, 0, len(apiErr.FieldErrors))
+	for field := range apiErr.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	unmatched := false
+	for _, field := range fields {
+		attrPath := path.Root(field)
+		if _, d := s.TypeAtPath(ctx, attrPath); d.HasError() {
+			unmatched = true
+			continue
+		}
+		diags.AddAttributeError(attrPath, summary, strings.Join(apiErr.FieldErrors[field], "\n"))
+	}
+
+	if unmatched {
+		diags.AddError(summary, detail)
+	}
+}
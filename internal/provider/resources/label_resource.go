@@ -0,0 +1,329 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ resource.Resource                = &LabelResource{}
+	_ resource.ResourceWithConfigure   = &LabelResource{}
+	_ resource.ResourceWithImportState = &LabelResource{}
+)
+
+func NewLabelResource() resource.Resource {
+	return &LabelResource{}
+}
+
+type LabelResource struct {
+	client *jumpserver.Client
+}
+
+type LabelResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Value    types.String   `tfsdk:"value"`
+	Key      types.String   `tfsdk:"key"`
+	Color    types.String   `tfsdk:"color"`
+	Comment  types.String   `tfsdk:"comment"`
+	ResCount types.Int64    `tfsdk:"res_count"`
+	OrgID    types.String   `tfsdk:"org_id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *LabelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_label"
+}
+
+func (r *LabelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a JumpServer label, a name:value pair such as env:prod that can be attached to assets",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The unique identifier of the label",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The label name (e.g., 'env')",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^:]+$`), "must not contain ':'"),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:    true,
+				Description: "The label value (e.g., 'prod')",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The label in name:value form, as used by the labels attribute of jumpserver_asset",
+			},
+			"color": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The display color of the label (e.g., '#1ab394')",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Additional comments about the label",
+			},
+			"res_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of resources the label is attached to",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": orgIDAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *LabelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *LabelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan LabelResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	createReq := &jumpserver.CreateLabelRequest{
+		Name:    plan.Name.ValueString(),
+		Value:   plan.Value.ValueString(),
+		Color:   plan.Color.ValueString(),
+		Comment: plan.Comment.ValueString(),
+	}
+
+	label, err := r.client.CreateLabel(ctx, createReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error creating label",
+			fmt.Sprintf("Could not create label: %s", err),
+			err,
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(label.ID)
+	r.setLabelState(label, &plan)
+
+	tflog.Trace(ctx, "created label", map[string]any{"id": plan.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *LabelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state LabelResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	label, err := r.client.GetLabel(ctx, state.ID.ValueString())
+	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "label no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading label",
+			fmt.Sprintf("Could not read label: %s", err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(label.ID)
+	r.setLabelState(label, &state)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *LabelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan LabelResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	updateReq := &jumpserver.UpdateLabelRequest{
+		Name:    plan.Name.ValueString(),
+		Value:   plan.Value.ValueString(),
+		Color:   plan.Color.ValueString(),
+		Comment: plan.Comment.ValueString(),
+	}
+
+	label, err := r.client.UpdateLabel(ctx, plan.ID.ValueString(), updateReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error updating label",
+			fmt.Sprintf("Could not update label: %s", err),
+			err,
+		)
+		return
+	}
+
+	r.setLabelState(label, &plan)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *LabelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state LabelResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	err := r.client.DeleteLabel(ctx, state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting label",
+			fmt.Sprintf("Could not delete label: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted label", map[string]any{"id": state.ID.ValueString()})
+}
+
+// ImportState accepts either a label ID or a name:value key
+func (r *LabelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, id := splitImportID(req.ID)
+	name, value, err := jumpserver.SplitLabelKey(id)
+	if err != nil {
+		importStateWithOrgID(ctx, req, resp)
+		return
+	}
+
+	if orgID != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgID)...)
+	}
+
+	label, err := r.client.GetLabelByKey(jumpserver.WithOrgID(ctx, orgID), name, value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing label",
+			fmt.Sprintf("Could not find label %s: %s", id, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), label.ID)...)
+}
+
+// setLabelState maps a label returned by the API onto the resource model
+func (r *LabelResource) setLabelState(label *jumpserver.Label, model *LabelResourceModel) {
+	model.Name = types.StringValue(label.Name)
+	model.Value = types.StringValue(label.Value)
+	model.Key = types.StringValue(label.Key())
+	model.Color = types.StringValue(label.Color)
+	model.ResCount = types.Int64Value(int64(label.ResCount))
+	if label.Comment != "" || !model.Comment.IsNull() {
+		model.Comment = types.StringValue(label.Comment)
+	}
+}
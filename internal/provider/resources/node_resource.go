@@ -0,0 +1,445 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ resource.Resource                = &NodeResource{}
+	_ resource.ResourceWithConfigure   = &NodeResource{}
+	_ resource.ResourceWithImportState = &NodeResource{}
+)
+
+func NewNodeResource() resource.Resource {
+	return &NodeResource{}
+}
+
+type NodeResource struct {
+	client *jumpserver.Client
+}
+
+type NodeResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Value       types.String   `tfsdk:"value"`
+	Parent      types.String   `tfsdk:"parent"`
+	Key         types.String   `tfsdk:"key"`
+	FullValue   types.String   `tfsdk:"full_value"`
+	ForceDelete types.Bool     `tfsdk:"force_delete"`
+	OrgID       types.String   `tfsdk:"org_id"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *NodeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node"
+}
+
+func (r *NodeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a node of the JumpServer asset tree",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The unique identifier of the node",
+			},
+			"value": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the node within its parent (e.g., 'Payments')",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]+$`), "must not contain '/'"),
+				},
+			},
+			"parent": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the parent node. Defaults to the root node of the organization. Changing it moves the node with its subtree",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The position of the node in the tree (e.g., '1:3:5')",
+			},
+			"full_value": schema.StringAttribute{
+				Computed:    true,
+				Description: "The full path of the node (e.g., '/Default/Prod/Payments')",
+			},
+			"force_delete": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Detach the assets of the node and its descendants so it can be deleted. Assets themselves are kept. By default deleting a node that still holds assets fails",
+			},
+			"org_id": orgIDAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *NodeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan NodeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	created, err := r.client.CreateNode(ctx, plan.Parent.ValueString(), &jumpserver.CreateNodeRequest{
+		Value: plan.Value.ValueString(),
+	})
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error creating node",
+			fmt.Sprintf("Could not create node: %s", err),
+			err,
+		)
+		return
+	}
+
+	// The children endpoint returns a partial node, so read it back in full
+	node, err := r.client.GetNode(ctx, created.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading node",
+			fmt.Sprintf("Could not read node ID %s after creating it: %s", created.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.setNodeState(ctx, node, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created node", map[string]any{"id": plan.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state NodeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	node, err := r.client.GetNode(ctx, state.ID.ValueString())
+	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "node no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading node",
+			fmt.Sprintf("Could not read node: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.setNodeState(ctx, node, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported nodes have no force_delete setting yet
+	if state.ForceDelete.IsNull() {
+		state.ForceDelete = types.BoolValue(false)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state NodeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	id := plan.ID.ValueString()
+
+	if !plan.Value.Equal(state.Value) {
+		_, err := r.client.UpdateNode(ctx, id, &jumpserver.UpdateNodeRequest{Value: plan.Value.ValueString()})
+		if err != nil {
+			addAPIError(
+				ctx, &resp.Diagnostics, req.Plan.Schema,
+				"Error updating node",
+				fmt.Sprintf("Could not rename node ID %s: %s", id, err),
+				err,
+			)
+			return
+		}
+	}
+
+	if !plan.Parent.IsUnknown() && !plan.Parent.Equal(state.Parent) {
+		if err := r.client.MoveNode(ctx, id, plan.Parent.ValueString()); err != nil {
+			addAPIError(
+				ctx, &resp.Diagnostics, req.Plan.Schema,
+				"Error updating node",
+				fmt.Sprintf("Could not move node ID %s below node ID %s: %s", id, plan.Parent.ValueString(), err),
+				err,
+			)
+			return
+		}
+	}
+
+	node, err := r.client.GetNode(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading node",
+			fmt.Sprintf("Could not read node ID %s after updating it: %s", id, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.setNodeState(ctx, node, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *NodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state NodeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	id := state.ID.ValueString()
+
+	assets, err := r.client.ListNodeAssets(ctx, id, true)
+	if err != nil && !jumpserver.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting node",
+			fmt.Sprintf("Could not list the assets of node ID %s: %s", id, err),
+		)
+		return
+	}
+
+	if len(assets) > 0 {
+		if !state.ForceDelete.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Node still holds assets",
+				fmt.Sprintf("Node %s and its descendants still hold %d assets. Move the assets elsewhere or set force_delete = true to detach them before deleting the node.",
+					state.FullValue.ValueString(), len(assets)),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(r.detachSubtreeAssets(ctx, state.Key.ValueString(), assets)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	err = r.client.DeleteNode(ctx, id)
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting node",
+			fmt.Sprintf("Could not delete node: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted node", map[string]any{"id": id})
+}
+
+// ImportState accepts either a node ID or a full path such as /Default/Prod
+func (r *NodeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, id := splitImportID(req.ID)
+	if !strings.HasPrefix(id, "/") {
+		importStateWithOrgID(ctx, req, resp)
+		return
+	}
+
+	if orgID != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgID)...)
+	}
+
+	node, err := r.client.GetNodeByFullName(jumpserver.WithOrgID(ctx, orgID), id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing node",
+			fmt.Sprintf("Could not find node %s: %s", id, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), node.ID)...)
+}
+
+// setNodeState maps a node returned by the API onto the resource model, resolving
+// the parent ID from the node key
+func (r *NodeResource) setNodeState(ctx context.Context, node *jumpserver.Node, model *NodeResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(node.ID)
+	model.Value = types.StringValue(node.Value)
+	model.Key = types.StringValue(node.Key)
+	model.FullValue = types.StringValue(node.FullName)
+
+	parentKey := node.ParentKey()
+	if parentKey == "" {
+		model.Parent = types.StringNull()
+		return diags
+	}
+
+	parent, err := r.client.GetNodeByKey(ctx, parentKey)
+	if err != nil {
+		diags.AddError(
+			"Error reading node",
+			fmt.Sprintf("Could not read the parent of node ID %s: %s", node.ID, err),
+		)
+		return diags
+	}
+	model.Parent = types.StringValue(parent.ID)
+
+	return diags
+}
+
+// detachSubtreeAssets removes the given assets from every node of the subtree rooted
+// at key, so that JumpServer allows the subtree to be deleted
+func (r *NodeResource) detachSubtreeAssets(ctx context.Context, key string, assets []jumpserver.Asset) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	nodes, err := r.client.ListNodes(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error deleting node",
+			fmt.Sprintf("Could not list nodes: %s", err),
+		)
+		return diags
+	}
+
+	subtree := make(map[string]bool)
+	for _, n := range nodes {
+		if n.Key == key || n.IsDescendantOf(key) {
+			subtree[n.ID] = true
+		}
+	}
+
+	byNode := make(map[string][]string)
+	for _, a := range assets {
+		for _, n := range a.Nodes {
+			if subtree[n.ID] {
+				byNode[n.ID] = append(byNode[n.ID], a.ID)
+			}
+		}
+	}
+
+	for nodeID, assetIDs := range byNode {
+		tflog.Debug(ctx, "detaching assets from node", map[string]any{"node": nodeID, "count": len(assetIDs)})
+		if err := r.client.RemoveNodeAssets(ctx, nodeID, assetIDs); err != nil {
+			diags.AddError(
+				"Error deleting node",
+				fmt.Sprintf("Could not detach assets from node ID %s: %s", nodeID, err),
+			)
+		}
+	}
+
+	return diags
+}
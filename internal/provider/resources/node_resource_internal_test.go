@@ -0,0 +1,155 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"jumpserver/internal/jumpserver"
+)
+
+// nodeServer serves a small node tree in which /Default/Prod holds asset a2 directly
+// and asset a1 through its child /Default/Prod/Web. Asset a1 is also attached to
+// /Default/Staging, outside the subtree. Every change is recorded with its body.
+func nodeServer(t *testing.T) (*httptest.Server, *[]string, map[string][]string) {
+	t.Helper()
+
+	var changes []string
+	bodies := make(map[string][]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/assets/nodes/":
+			w.Write([]byte(`[
+				{"id":"n0","key":"1","value":"Default","full_value":"/Default"},
+				{"id":"n1","key":"1:3","value":"Prod","full_value":"/Default/Prod"},
+				{"id":"n2","key":"1:3:1","value":"Web","full_value":"/Default/Prod/Web"},
+				{"id":"n9","key":"1:9","value":"Staging","full_value":"/Default/Staging"}
+			]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/assets/assets/":
+			w.Write([]byte(`[
+				{"id":"a1","name":"web1","nodes":[{"id":"n2"},{"id":"n9"}]},
+				{"id":"a2","name":"db1","nodes":[{"id":"n1"}]}
+			]`))
+		default:
+			change := r.Method + " " + r.URL.Path
+			changes = append(changes, change)
+			var body map[string][]string
+			json.NewDecoder(r.Body).Decode(&body)
+			bodies[change] = body["assets"]
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &changes, bodies
+}
+
+func TestNodeDeleteRefusesNodeWithAssets(t *testing.T) {
+	ctx := context.Background()
+	server, changes, _ := nodeServer(t)
+	r := &NodeResource{client: jumpserver.NewClient(&jumpserver.Config{Endpoint: server.URL})}
+
+	raw, nodeSchema := resourceValue(t, r, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "n1"),
+		"key":          tftypes.NewValue(tftypes.String, "1:3"),
+		"full_value":   tftypes.NewValue(tftypes.String, "/Default/Prod"),
+		"force_delete": tftypes.NewValue(tftypes.Bool, false),
+	})
+	state := tfsdk.State{Schema: nodeSchema, Raw: raw}
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	errs := resp.Diagnostics.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "Node still holds assets" {
+		t.Fatalf("expected the delete to be refused, got %v", resp.Diagnostics)
+	}
+	if len(*changes) != 0 {
+		t.Errorf("expected no changes, got %v", *changes)
+	}
+}
+
+func TestNodeDeleteForceDetachesSubtreeAssets(t *testing.T) {
+	ctx := context.Background()
+	server, changes, bodies := nodeServer(t)
+	r := &NodeResource{client: jumpserver.NewClient(&jumpserver.Config{Endpoint: server.URL})}
+
+	raw, nodeSchema := resourceValue(t, r, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "n1"),
+		"key":          tftypes.NewValue(tftypes.String, "1:3"),
+		"full_value":   tftypes.NewValue(tftypes.String, "/Default/Prod"),
+		"force_delete": tftypes.NewValue(tftypes.Bool, true),
+	})
+	state := tfsdk.State{Schema: nodeSchema, Raw: raw}
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	// Detaching happens per node in map order, so only the final delete has a fixed position
+	if len(*changes) != 3 || (*changes)[2] != "DELETE /api/v1/assets/nodes/n1/" {
+		t.Fatalf("expected two detaches followed by the delete, got %v", *changes)
+	}
+	wantDetached := map[string][]string{
+		"PUT /api/v1/assets/nodes/n1/assets/remove/": {"a2"},
+		"PUT /api/v1/assets/nodes/n2/assets/remove/": {"a1"},
+	}
+	for change, want := range wantDetached {
+		if got := bodies[change]; !slices.Equal(got, want) {
+			t.Errorf("%s: expected assets %v, got %v", change, want, got)
+		}
+	}
+	if slices.Contains(*changes, "PUT /api/v1/assets/nodes/n9/assets/remove/") {
+		t.Error("expected assets to stay attached to nodes outside the subtree")
+	}
+}
+
+func TestNodeImportStateAcceptsIDOrPath(t *testing.T) {
+	ctx := context.Background()
+	const org = "00000000-0000-0000-0000-000000000002"
+	server, _, _ := nodeServer(t)
+	r := &NodeResource{client: jumpserver.NewClient(&jumpserver.Config{Endpoint: server.URL})}
+
+	tests := []struct {
+		id        string
+		wantID    string
+		wantOrgID types.String
+		wantError bool
+	}{
+		{"n1", "n1", types.StringNull(), false},
+		{org + "/n1", "n1", types.StringValue(org), false},
+		{"/Default/Prod", "n1", types.StringNull(), false},
+		{org + "//Default/Prod/Web", "n2", types.StringValue(org), false},
+		{"/Default/Missing", "", types.StringNull(), true},
+	}
+
+	for _, tt := range tests {
+		raw, nodeSchema := resourceValue(t, r, nil)
+		resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: nodeSchema, Raw: tftypes.NewValue(raw.Type(), nil)}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Fatalf("%s: expected error %t, got %v", tt.id, tt.wantError, resp.Diagnostics)
+		}
+		if tt.wantError {
+			continue
+		}
+
+		var id, orgID types.String
+		resp.State.GetAttribute(ctx, path.Root("id"), &id)
+		resp.State.GetAttribute(ctx, path.Root("org_id"), &orgID)
+		if id.ValueString() != tt.wantID || !orgID.Equal(tt.wantOrgID) {
+			t.Errorf("%s: expected id %s in org %s, got %s in %s", tt.id, tt.wantID, tt.wantOrgID, id, orgID)
+		}
+	}
+}
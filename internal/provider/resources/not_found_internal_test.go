@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestResourcesHandleNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"detail":"Not found."}`))
+	}))
+	defer server.Close()
+
+	client := jumpserver.NewClient(&jumpserver.Config{Endpoint: server.URL})
+
+	tests := []struct {
+		name     string
+		resource resource.ResourceWithConfigure
+		values   map[string]tftypes.Value
+	}{
+		{"account", &AccountResource{}, nil},
+		{"asset", &AssetResource{}, map[string]tftypes.Value{
+			"category": tftypes.NewValue(tftypes.String, jumpserver.AssetCategoryHost),
+		}},
+		{"permission", &PermissionResource{}, nil},
+		{"user", &UserResource{}, nil},
+	}
+
+	for _, tt := range tests {
+		requests = nil
+		configureResp := &resource.ConfigureResponse{}
+		tt.resource.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, configureResp)
+		if configureResp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected configure error: %v", tt.name, configureResp.Diagnostics)
+		}
+
+		values := map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.String, "gone")}
+		for name, value := range tt.values {
+			values[name] = value
+		}
+		raw, s := resourceValue(t, tt.resource, values)
+		state := tfsdk.State{Schema: s, Raw: raw}
+
+		readResp := &resource.ReadResponse{State: state}
+		tt.resource.Read(ctx, resource.ReadRequest{State: state}, readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Errorf("%s: unexpected read error: %v", tt.name, readResp.Diagnostics)
+		}
+		if !readResp.State.Raw.IsNull() {
+			t.Errorf("%s: expected the resource to be removed from state", tt.name)
+		}
+
+		deleteResp := &resource.DeleteResponse{State: state}
+		tt.resource.Delete(ctx, resource.DeleteRequest{State: state}, deleteResp)
+		if deleteResp.Diagnostics.HasError() {
+			t.Errorf("%s: unexpected delete error: %v", tt.name, deleteResp.Diagnostics)
+		}
+
+		if len(requests) != 2 {
+			t.Errorf("%s: expected one read and one delete request, got %v", tt.name, requests)
+		}
+	}
+}
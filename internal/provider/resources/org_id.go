@@ -0,0 +1,47 @@
+package resources
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+// orgIDPattern matches organization IDs, which are UUIDs
+var orgIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// orgIDAttribute returns the org_id attribute shared by resources that live in an organization
+func orgIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "ID of the organization the resource belongs to. Overrides the provider org_id for this resource. Changing it forces a new resource",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// splitImportID splits an import ID of the form <org_id>/<id>. IDs that do not start
+// with an organization ID are returned unchanged with an empty organization.
+func splitImportID(id string) (orgID, rest string) {
+	prefix, rest, ok := strings.Cut(id, "/")
+	if !ok || !orgIDPattern.MatchString(prefix) || rest == "" {
+		return "", id
+	}
+	return prefix, rest
+}
+
+// importStateWithOrgID imports a resource by <id> or <org_id>/<id>. The organization is
+// stored in org_id before Read so the resource is looked up in it.
+func importStateWithOrgID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, id := splitImportID(req.ID)
+	if orgID != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgID)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
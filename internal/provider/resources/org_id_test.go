@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSplitImportID(t *testing.T) {
+	const org = "00000000-0000-0000-0000-000000000002"
+
+	tests := []struct {
+		id, wantOrg, wantRest string
+	}{
+		{"0a1b2c3d-0000-4000-8000-000000000001", "", "0a1b2c3d-0000-4000-8000-000000000001"},
+		{org + "/0a1b2c3d-0000-4000-8000-000000000001", org, "0a1b2c3d-0000-4000-8000-000000000001"},
+		{org + "/Linux", org, "Linux"},
+		{org + "//Default/Prod", org, "/Default/Prod"},
+		{"/Default/Prod", "", "/Default/Prod"},
+		{"env:prod/eu", "", "env:prod/eu"},
+		{org + "/", "", org + "/"},
+	}
+
+	for _, tt := range tests {
+		gotOrg, gotRest := splitImportID(tt.id)
+		if gotOrg != tt.wantOrg || gotRest != tt.wantRest {
+			t.Errorf("%q: expected (%q, %q), got (%q, %q)", tt.id, tt.wantOrg, tt.wantRest, gotOrg, gotRest)
+		}
+	}
+}
+
+func TestImportStateWithOrgIDSetsOrgID(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&UserGroupResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	importStateWithOrgID(ctx, resource.ImportStateRequest{ID: "00000000-0000-0000-0000-000000000002/g1"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var id, orgID types.String
+	resp.State.GetAttribute(ctx, path.Root("id"), &id)
+	resp.State.GetAttribute(ctx, path.Root("org_id"), &orgID)
+	if id.ValueString() != "g1" || orgID.ValueString() != "00000000-0000-0000-0000-000000000002" {
+		t.Errorf("expected id g1 in organization 00000000-0000-0000-0000-000000000002, got %s in %s", id, orgID)
+	}
+}
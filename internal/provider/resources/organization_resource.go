@@ -0,0 +1,398 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ resource.Resource                = &OrganizationResource{}
+	_ resource.ResourceWithConfigure   = &OrganizationResource{}
+	_ resource.ResourceWithImportState = &OrganizationResource{}
+)
+
+func NewOrganizationResource() resource.Resource {
+	return &OrganizationResource{}
+}
+
+type OrganizationResource struct {
+	client *jumpserver.Client
+}
+
+type OrganizationResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Comment  types.String   `tfsdk:"comment"`
+	Admins   types.Set      `tfsdk:"admins"`
+	Auditors types.Set      `tfsdk:"auditors"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *OrganizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (r *OrganizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a JumpServer organization and, optionally, its administrators and auditors",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The unique identifier of the organization, usable as org_id on other resources",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the organization",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Additional comments about the organization",
+			},
+			"admins": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "IDs of the users holding the organization admin role. When set, the list is authoritative and admins granted outside Terraform are removed",
+			},
+			"auditors": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "IDs of the users holding the organization auditor role. When set, the list is authoritative and auditors granted outside Terraform are removed",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *OrganizationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OrganizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan OrganizationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	createReq := &jumpserver.CreateOrganizationRequest{
+		Name:    plan.Name.ValueString(),
+		Comment: plan.Comment.ValueString(),
+	}
+
+	org, err := r.client.CreateOrganization(ctx, createReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error creating organization",
+			fmt.Sprintf("Could not create organization: %s", err),
+			err,
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(org.ID)
+	setOrganizationState(org, &plan)
+
+	// Save the organization before granting roles so a failed binding does not orphan
+	// it; the members are recorded as unmanaged until the bindings succeed
+	created := plan
+	created.Admins = types.SetNull(types.StringType)
+	created.Auditors = types.SetNull(types.StringType)
+	diags = resp.State.Set(ctx, created)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.syncRoles(ctx, org.ID, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created organization", map[string]any{"id": plan.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *OrganizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state OrganizationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	org, err := r.client.GetOrganization(ctx, state.ID.ValueString())
+	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "organization no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading organization",
+			fmt.Sprintf("Could not read organization: %s", err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(org.ID)
+	setOrganizationState(org, &state)
+
+	// Members are only tracked when managed, so unmanaged roles never show up as drift
+	if !state.Admins.IsNull() {
+		state.Admins, diags = r.readRoleMembers(ctx, org.ID, jumpserver.OrgAdminRoleID)
+		resp.Diagnostics.Append(diags...)
+	}
+	if !state.Auditors.IsNull() {
+		state.Auditors, diags = r.readRoleMembers(ctx, org.ID, jumpserver.OrgAuditorRoleID)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *OrganizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan OrganizationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	updateReq := &jumpserver.UpdateOrganizationRequest{
+		Name:    plan.Name.ValueString(),
+		Comment: plan.Comment.ValueString(),
+	}
+
+	org, err := r.client.UpdateOrganization(ctx, plan.ID.ValueString(), updateReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error updating organization",
+			fmt.Sprintf("Could not update organization: %s", err),
+			err,
+		)
+		return
+	}
+
+	setOrganizationState(org, &plan)
+
+	resp.Diagnostics.Append(r.syncRoles(ctx, org.ID, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *OrganizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state OrganizationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteOrganization(ctx, state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting organization",
+			fmt.Sprintf("Could not delete organization: %s. Organizations that still contain assets, users or permissions cannot be deleted.", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted organization", map[string]any{"id": state.ID.ValueString()})
+}
+
+func (r *OrganizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// syncRoles makes the admin and auditor role bindings of the organization match the
+// plan. Roles whose attribute is not set are left untouched.
+func (r *OrganizationResource) syncRoles(ctx context.Context, orgID string, plan *OrganizationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	roles := []struct {
+		attr   string
+		roleID string
+		users  types.Set
+	}{
+		{"admins", jumpserver.OrgAdminRoleID, plan.Admins},
+		{"auditors", jumpserver.OrgAuditorRoleID, plan.Auditors},
+	}
+	for _, role := range roles {
+		if role.users.IsNull() {
+			continue
+		}
+		if err := r.syncRoleMembers(ctx, orgID, role.roleID, toStringSet(role.users)); err != nil {
+			diags.AddAttributeError(
+				path.Root(role.attr),
+				"Error updating organization members",
+				fmt.Sprintf("Could not update the %s of organization %s: %s", role.attr, orgID, err),
+			)
+		}
+	}
+
+	return diags
+}
+
+// syncRoleMembers grants the role to every desired user that lacks it and revokes it
+// from every other user
+func (r *OrganizationResource) syncRoleMembers(ctx context.Context, orgID, roleID string, users []string) error {
+	ctx = jumpserver.WithOrgID(ctx, orgID)
+
+	bindings, err := r.client.ListRoleBindings(ctx, jumpserver.RoleScopeOrg, roleID)
+	if err != nil {
+		return err
+	}
+
+	desired := make(map[string]bool, len(users))
+	for _, u := range users {
+		desired[u] = true
+	}
+
+	for _, b := range bindings {
+		if desired[b.GetUserID()] {
+			delete(desired, b.GetUserID())
+			continue
+		}
+		if err := r.client.DeleteRoleBinding(ctx, jumpserver.RoleScopeOrg, b.ID); err != nil && !jumpserver.IsNotFound(err) {
+			return fmt.Errorf("revoking role from user %s: %w", b.GetUserID(), err)
+		}
+	}
+
+	for _, u := range users {
+		if !desired[u] {
+			continue
+		}
+		_, err := r.client.CreateRoleBinding(ctx, jumpserver.RoleScopeOrg, &jumpserver.CreateRoleBindingRequest{
+			User: u,
+			Role: roleID,
+			Org:  orgID,
+		})
+		if err != nil {
+			return fmt.Errorf("granting role to user %s: %w", u, err)
+		}
+	}
+
+	return nil
+}
+
+// readRoleMembers returns the IDs of the users bound to the role within the organization
+func (r *OrganizationResource) readRoleMembers(ctx context.Context, orgID, roleID string) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	bindings, err := r.client.ListRoleBindings(jumpserver.WithOrgID(ctx, orgID), jumpserver.RoleScopeOrg, roleID)
+	if err != nil {
+		diags.AddError(
+			"Error reading organization members",
+			fmt.Sprintf("Could not list the role bindings of organization %s: %s", orgID, err),
+		)
+		return types.SetNull(types.StringType), diags
+	}
+
+	users := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		users = append(users, b.GetUserID())
+	}
+
+	set, d := types.SetValueFrom(ctx, types.StringType, users)
+	diags.Append(d...)
+	return set, diags
+}
+
+// setOrganizationState maps an organization returned by the API onto the resource model
+func setOrganizationState(org *jumpserver.Organization, model *OrganizationResourceModel) {
+	model.Name = types.StringValue(org.Name)
+	if org.Comment != "" || !model.Comment.IsNull() {
+		model.Comment = types.StringValue(org.Comment)
+	}
+}
@@ -2,16 +2,26 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -20,11 +30,27 @@ import (
 )
 
 var (
-	_ resource.Resource                = &PermissionResource{}
-	_ resource.ResourceWithConfigure   = &PermissionResource{}
-	_ resource.ResourceWithImportState = &PermissionResource{}
+	_ resource.Resource                   = &PermissionResource{}
+	_ resource.ResourceWithConfigure      = &PermissionResource{}
+	_ resource.ResourceWithImportState    = &PermissionResource{}
+	_ resource.ResourceWithValidateConfig = &PermissionResource{}
+	_ resource.ResourceWithModifyPlan     = &PermissionResource{}
 )
 
+// permissionDatesKey names the private state entry recording which dates are set in
+// the configuration, so that removing a date can be told apart from never setting it
+const permissionDatesKey = "configured_dates"
+
+// permissionLifetimeYears is how long a permission lasts without an expiry date,
+// matching the JumpServer default
+const permissionLifetimeYears = 70
+
+// permissionDates records which dates of a permission are set in the configuration
+type permissionDates struct {
+	DateStart   bool `json:"date_start"`
+	DateExpired bool `json:"date_expired"`
+}
+
 func NewPermissionResource() resource.Resource {
 	return &PermissionResource{}
 }
@@ -34,14 +60,22 @@ type PermissionResource struct {
 }
 
 type PermissionResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Users       types.Set    `tfsdk:"users"`
-	UserGroups  types.Set    `tfsdk:"user_groups"`
-	Assets      types.Set    `tfsdk:"assets"`
-	AssetGroups types.Set    `tfsdk:"asset_groups"`
-	Actions     types.Set    `tfsdk:"actions"`
-	Comment     types.String `tfsdk:"comment"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Users       types.Set      `tfsdk:"users"`
+	UserGroups  types.Set      `tfsdk:"user_groups"`
+	Assets      types.Set      `tfsdk:"assets"`
+	AssetGroups types.Set      `tfsdk:"asset_groups"`
+	Nodes       types.Set      `tfsdk:"nodes"`
+	Accounts    types.Set      `tfsdk:"accounts"`
+	Protocols   types.Set      `tfsdk:"protocols"`
+	Actions     types.Set      `tfsdk:"actions"`
+	IsActive    types.Bool     `tfsdk:"is_active"`
+	DateStart   types.String   `tfsdk:"date_start"`
+	DateExpired types.String   `tfsdk:"date_expired"`
+	Comment     types.String   `tfsdk:"comment"`
+	OrgID       types.String   `tfsdk:"org_id"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *PermissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -79,22 +113,97 @@ func (r *PermissionResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Description: "List of asset IDs to grant access to",
 			},
 			"asset_groups": schema.SetAttribute{
+				ElementType:        types.StringType,
+				Optional:           true,
+				Description:        "List of asset group IDs to grant access to",
+				DeprecationMessage: "JumpServer has no asset groups and ignores this attribute. Use nodes to grant access to every asset below a node.",
+			},
+			"nodes": schema.SetAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
-				Description: "List of asset group IDs to grant access to",
+				Description: "List of node IDs whose assets, including those of descendant nodes, are granted",
+			},
+			"accounts": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "Accounts the users may log in with: '@ALL' for every account, '@SPEC' together with account names to allow only those, '@INPUT' for credentials typed at login, '@USER' for the JumpServer user's own credentials and '@ANON' for no credentials. Without at least one entry the permission grants no usable account",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+						stringvalidator.RegexMatches(
+							regexp.MustCompile(`^(@ALL|@SPEC|@INPUT|@USER|@ANON|[^@].*)$`),
+							"must be @ALL, @SPEC, @INPUT, @USER, @ANON or an account name",
+						),
+					),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"protocols": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "Protocols the users may connect with (e.g., 'ssh', 'rdp', 'mysql'), or 'all'. Defaults to 'all'",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z0-9]+$`), "must be a lowercase protocol name or 'all'"),
+					),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"actions": schema.SetAttribute{
 				ElementType: types.StringType,
 				Required:    true,
 				Validators: []validator.Set{
-					setvalidator.ValueStringsAre(stringvalidator.OneOf("connect", "upload", "download", "clipboard", "delete_file")),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(permissionActionNames...)),
+				},
+				Description: "Allowed actions: 'connect', 'upload', 'download', 'copy', 'paste', 'delete' and 'share', or the groups 'transfer' (upload, download and delete), 'clipboard' (copy and paste) and 'all'. Groups are kept as written as long as the server grants exactly the actions they contain",
+			},
+			"is_active": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the permission is in effect. Defaults to true",
+			},
+			"date_start": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "When the permission takes effect, as an RFC 3339 timestamp (e.g., '2026-01-01T09:00:00+08:00'). Defaults to the creation time; removing it makes the permission take effect immediately",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rfc3339Pattern, "must be an RFC 3339 timestamp such as 2026-01-01T09:00:00Z"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"date_expired": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "When the permission stops granting access, as an RFC 3339 timestamp. Must be after date_start. Defaults to 70 years from the time it is applied, also when it is removed from the configuration",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rfc3339Pattern, "must be an RFC 3339 timestamp such as 2026-03-31T18:00:00Z"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
-				Description: "Allowed actions (e.g., 'connect', 'upload', 'download')",
 			},
 			"comment": schema.StringAttribute{
 				Optional:    true,
 				Description: "Additional comments about the permission",
 			},
+			"org_id": orgIDAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -116,6 +225,120 @@ func (r *PermissionResource) Configure(ctx context.Context, req resource.Configu
 	r.client = client
 }
 
+func (r *PermissionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config PermissionResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Accounts.IsNull() && !config.Accounts.IsUnknown() {
+		resp.Diagnostics.Append(validatePermissionAccounts(ctx, config.Accounts)...)
+	}
+
+	if !config.Protocols.IsNull() && !config.Protocols.IsUnknown() {
+		var protocols []types.String
+		resp.Diagnostics.Append(config.Protocols.ElementsAs(ctx, &protocols, false)...)
+		for _, p := range protocols {
+			if p.ValueString() == "all" && len(protocols) > 1 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("protocols"),
+					"Conflicting protocols",
+					"'all' already allows every protocol and cannot be combined with specific protocols.",
+				)
+			}
+		}
+	}
+
+	if config.DateStart.IsNull() || config.DateStart.IsUnknown() || config.DateExpired.IsNull() || config.DateExpired.IsUnknown() {
+		return
+	}
+
+	// Malformed dates are reported by the attribute validators
+	start, err := time.Parse(time.RFC3339, config.DateStart.ValueString())
+	if err != nil {
+		return
+	}
+	expired, err := time.Parse(time.RFC3339, config.DateExpired.ValueString())
+	if err != nil {
+		return
+	}
+
+	if !start.Before(expired) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("date_expired"),
+			"Invalid validity window",
+			fmt.Sprintf("date_expired (%s) must be after date_start (%s).", config.DateExpired.ValueString(), config.DateStart.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan plans the server defaults for dates removed from the configuration.
+// Leaving a date out of an update keeps its old value, so the default is sent instead.
+func (r *PermissionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	data, diags := req.Private.GetKey(ctx, permissionDatesKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || data == nil {
+		return
+	}
+
+	var configured permissionDates
+	if err := json.Unmarshal(data, &configured); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid private state",
+			fmt.Sprintf("Could not read the configured permission dates: %s", err),
+		)
+		return
+	}
+
+	var config PermissionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	var plan PermissionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configured.planDefaults(config, &plan, time.Now())
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("date_start"), plan.DateStart)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("date_expired"), plan.DateExpired)...)
+}
+
+// planDefaults sets the planned dates that were configured before but are no longer
+// to the JumpServer defaults at the given time
+func (d permissionDates) planDefaults(config PermissionResourceModel, plan *PermissionResourceModel, now time.Time) {
+	now = now.UTC().Truncate(time.Second)
+	if d.DateStart && config.DateStart.IsNull() {
+		plan.DateStart = types.StringValue(now.Format(time.RFC3339))
+	}
+	if d.DateExpired && config.DateExpired.IsNull() {
+		plan.DateExpired = types.StringValue(now.AddDate(permissionLifetimeYears, 0, 0).Format(time.RFC3339))
+	}
+}
+
+// configuredPermissionDates encodes which dates are set in the configuration for the
+// private state
+func configuredPermissionDates(ctx context.Context, config tfsdk.Config) ([]byte, diag.Diagnostics) {
+	var start, expired types.String
+	var diags diag.Diagnostics
+	diags.Append(config.GetAttribute(ctx, path.Root("date_start"), &start)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("date_expired"), &expired)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	data, err := json.Marshal(permissionDates{DateStart: !start.IsNull(), DateExpired: !expired.IsNull()})
+	if err != nil {
+		diags.AddError("Error encoding private state", err.Error())
+	}
+	return data, diags
+}
+
 func toStringSet(set basetypes.SetValue) []string {
 	var result []string
 	if !set.IsUnknown() && !set.IsNull() {
@@ -132,38 +355,58 @@ func (r *PermissionResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
 	createReq := &jumpserver.CreatePermissionRequest{
 		Name:        plan.Name.ValueString(),
 		Users:       toStringSet(plan.Users),
 		UserGroups:  toStringSet(plan.UserGroups),
 		Assets:      toStringSet(plan.Assets),
 		AssetGroups: toStringSet(plan.AssetGroups),
-		Actions:     toStringSet(plan.Actions),
+		Nodes:       toStringSet(plan.Nodes),
+		Accounts:    toStringSet(plan.Accounts),
+		Protocols:   toStringSet(plan.Protocols),
+		Actions:     expandPermissionActions(plan.Actions),
+		IsActive:    plan.IsActive.ValueBool(),
+		DateStart:   plan.DateStart.ValueString(),
+		DateExpired: plan.DateExpired.ValueString(),
 		Comment:     plan.Comment.ValueString(),
 	}
 
-	permission, err := r.client.CreatePermission(createReq)
+	permission, err := r.client.CreatePermission(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
 			"Error creating permission",
 			fmt.Sprintf("Could not create permission: %s", err),
+			err,
 		)
 		return
 	}
 
 	plan.ID = types.StringValue(permission.ID)
-	plan.Name = types.StringValue(permission.Name)
-	plan.Comment = types.StringValue(permission.Comment)
-	plan.Users, _ = types.SetValueFrom(ctx, types.StringType, permission.GetUserIDs())
-	plan.UserGroups, _ = types.SetValueFrom(ctx, types.StringType, permission.UserGroups)
-	plan.Assets, _ = types.SetValueFrom(ctx, types.StringType, permission.GetAssetIDs())
-	plan.AssetGroups, _ = types.SetValueFrom(ctx, types.StringType, permission.AssetGroups)
-	plan.Actions, _ = types.SetValueFrom(ctx, types.StringType, permission.GetActionValues())
+	resp.Diagnostics.Append(setPermissionState(ctx, permission, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Trace(ctx, "created permission", map[string]any{"id": permission.ID})
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+
+	dates, diags := configuredPermissionDates(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, permissionDatesKey, dates)...)
 }
 
 func (r *PermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -174,8 +417,25 @@ func (r *PermissionResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	permission, err := r.client.GetPermission(state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	permission, err := r.client.GetPermission(ctx, state.ID.ValueString())
 	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "permission no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error reading permission",
 			fmt.Sprintf("Could not read permission: %s", err),
@@ -183,13 +443,10 @@ func (r *PermissionResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	state.Name = types.StringValue(permission.Name)
-	state.Comment = types.StringValue(permission.Comment)
-	state.Users, _ = types.SetValueFrom(ctx, types.StringType, permission.GetUserIDs())
-	state.UserGroups, _ = types.SetValueFrom(ctx, types.StringType, permission.UserGroups)
-	state.Assets, _ = types.SetValueFrom(ctx, types.StringType, permission.GetAssetIDs())
-	state.AssetGroups, _ = types.SetValueFrom(ctx, types.StringType, permission.AssetGroups)
-	state.Actions, _ = types.SetValueFrom(ctx, types.StringType, permission.GetActionValues())
+	resp.Diagnostics.Append(setPermissionState(ctx, permission, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -203,35 +460,55 @@ func (r *PermissionResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
 	updateReq := &jumpserver.UpdatePermissionRequest{
 		Name:        plan.Name.ValueString(),
-		Users:       toStringSet(plan.Users),
-		UserGroups:  toStringSet(plan.UserGroups),
-		Assets:      toStringSet(plan.Assets),
-		AssetGroups: toStringSet(plan.AssetGroups),
-		Actions:     toStringSet(plan.Actions),
+		Users:       nonNil(toStringSet(plan.Users)),
+		UserGroups:  nonNil(toStringSet(plan.UserGroups)),
+		Assets:      nonNil(toStringSet(plan.Assets)),
+		AssetGroups: nonNil(toStringSet(plan.AssetGroups)),
+		Nodes:       nonNil(toStringSet(plan.Nodes)),
+		Accounts:    toStringSet(plan.Accounts),
+		Protocols:   toStringSet(plan.Protocols),
+		Actions:     expandPermissionActions(plan.Actions),
+		IsActive:    plan.IsActive.ValueBoolPointer(),
+		DateStart:   plan.DateStart.ValueString(),
+		DateExpired: plan.DateExpired.ValueString(),
 		Comment:     plan.Comment.ValueString(),
 	}
 
-	permission, err := r.client.UpdatePermission(plan.ID.ValueString(), updateReq)
+	permission, err := r.client.UpdatePermission(ctx, plan.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
 			"Error updating permission",
 			fmt.Sprintf("Could not update permission: %s", err),
+			err,
 		)
 		return
 	}
 
-	plan.Name = types.StringValue(permission.Name)
-	plan.Comment = types.StringValue(permission.Comment)
-	plan.Users, _ = types.SetValueFrom(ctx, types.StringType, permission.GetUserIDs())
-	plan.UserGroups, _ = types.SetValueFrom(ctx, types.StringType, permission.UserGroups)
-	plan.Assets, _ = types.SetValueFrom(ctx, types.StringType, permission.GetAssetIDs())
-	plan.AssetGroups, _ = types.SetValueFrom(ctx, types.StringType, permission.AssetGroups)
-	plan.Actions, _ = types.SetValueFrom(ctx, types.StringType, permission.GetActionValues())
+	resp.Diagnostics.Append(setPermissionState(ctx, permission, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+
+	dates, diags := configuredPermissionDates(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, permissionDatesKey, dates)...)
 }
 
 func (r *PermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -242,8 +519,20 @@ func (r *PermissionResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.client.DeletePermission(state.ID.ValueString())
-	if err != nil {
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	err := r.client.DeletePermission(ctx, state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting permission",
 			fmt.Sprintf("Could not delete permission: %s", err),
@@ -255,5 +544,142 @@ func (r *PermissionResource) Delete(ctx context.Context, req resource.DeleteRequ
 }
 
 func (r *PermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithOrgID(ctx, req, resp)
+}
+
+// setPermissionState maps a permission returned by the API onto the resource model
+func setPermissionState(ctx context.Context, permission *jumpserver.Permission, model *PermissionResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.Name = types.StringValue(permission.Name)
+	model.Comment = types.StringValue(permission.Comment)
+	model.IsActive = types.BoolValue(permission.IsActive)
+	model.Users, _ = optionalSetValue(ctx, permission.GetUserIDs(), model.Users)
+	model.UserGroups, _ = optionalSetValue(ctx, permission.UserGroups, model.UserGroups)
+	model.Assets, _ = optionalSetValue(ctx, permission.GetAssetIDs(), model.Assets)
+	model.AssetGroups, _ = optionalSetValue(ctx, permission.AssetGroups, model.AssetGroups)
+	model.Nodes, _ = optionalSetValue(ctx, permission.GetNodeIDs(), model.Nodes)
+	model.Accounts, _ = types.SetValueFrom(ctx, types.StringType, nonNil(permission.Accounts))
+	model.Protocols, _ = types.SetValueFrom(ctx, types.StringType, nonNil(permission.Protocols))
+	actions, d := permissionActionsValue(ctx, permission, model.Actions)
+	diags.Append(d...)
+	model.Actions = actions
+
+	var err error
+	if model.DateStart, err = timeValue(permission.DateStart, model.DateStart); err != nil {
+		diags.AddAttributeError(
+			path.Root("date_start"),
+			"Invalid start date",
+			fmt.Sprintf("Could not parse the start date of permission %s: %s", permission.Name, err),
+		)
+	}
+	if model.DateExpired, err = timeValue(permission.DateExpired, model.DateExpired); err != nil {
+		diags.AddAttributeError(
+			path.Root("date_expired"),
+			"Invalid expiry date",
+			fmt.Sprintf("Could not parse the expiry date of permission %s: %s", permission.Name, err),
+		)
+	}
+
+	return diags
+}
+
+// permissionActionNames are the values accepted in actions: the atomic actions, the
+// groups JumpServer offers and delete_file, which earlier versions of this provider used
+var permissionActionNames = append(append([]string{}, jumpserver.Actions...), "all", "transfer", "clipboard", "delete_file")
+
+// expandPermissionActions resolves the configured actions into the atomic actions sent to the API
+func expandPermissionActions(set types.Set) []string {
+	actions, _ := jumpserver.ExpandActions(toStringSet(set))
+	return actions
+}
+
+// permissionActionsValue maps the actions returned by the API onto the state. The prior
+// value is kept when it expands to the same actions, so groups such as 'all' and the
+// order or spelling of the configuration do not drift. Actions the provider does not
+// know are reported as a warning and left out.
+func permissionActionsValue(ctx context.Context, permission *jumpserver.Permission, prior types.Set) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	actions, unknown := jumpserver.ExpandActions(permission.GetActionValues())
+	if len(unknown) > 0 {
+		tflog.Warn(ctx, "permission has unknown actions", map[string]any{"id": permission.ID, "actions": unknown})
+		diags.AddAttributeWarning(
+			path.Root("actions"),
+			"Unknown permission actions",
+			fmt.Sprintf("JumpServer reports actions this provider does not know for permission %s: %s. They are left out of the state and are removed on the next update.", permission.Name, strings.Join(unknown, ", ")),
+		)
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if expanded, _ := jumpserver.ExpandActions(toStringSet(prior)); slices.Equal(expanded, actions) {
+			return prior, diags
+		}
+	}
+
+	set, d := types.SetValueFrom(ctx, types.StringType, nonNil(actions))
+	diags.Append(d...)
+	return set, diags
+}
+
+// validatePermissionAccounts checks that account names are only used together with @SPEC
+// and that aliases are not combined in ways JumpServer would silently ignore
+func validatePermissionAccounts(ctx context.Context, accounts types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var values []types.String
+	diags.Append(accounts.ElementsAs(ctx, &values, false)...)
+
+	aliases := make(map[string]bool)
+	var names []string
+	for _, v := range values {
+		if v.IsUnknown() {
+			return diags
+		}
+		if strings.HasPrefix(v.ValueString(), "@") {
+			aliases[v.ValueString()] = true
+		} else {
+			names = append(names, v.ValueString())
+		}
+	}
+
+	switch {
+	case len(names) > 0 && !aliases[jumpserver.AccountAliasSpec]:
+		diags.AddAttributeError(
+			path.Root("accounts"),
+			"Account names without @SPEC",
+			fmt.Sprintf("Account names (%s) are only honored together with @SPEC. Add \"@SPEC\" to accounts.", strings.Join(names, ", ")),
+		)
+	case aliases[jumpserver.AccountAliasSpec] && len(names) == 0:
+		diags.AddAttributeError(
+			path.Root("accounts"),
+			"@SPEC without account names",
+			"@SPEC allows only the accounts listed by name, but no account names are listed.",
+		)
+	case aliases[jumpserver.AccountAliasAll] && aliases[jumpserver.AccountAliasSpec]:
+		diags.AddAttributeError(
+			path.Root("accounts"),
+			"Conflicting account aliases",
+			"@ALL already allows every account and cannot be combined with @SPEC.",
+		)
+	}
+
+	return diags
+}
+
+// optionalSetValue converts IDs returned by the API into a set. An empty result keeps a
+// null value null so omitting the attribute does not produce a diff.
+func optionalSetValue(ctx context.Context, values []string, current types.Set) (types.Set, diag.Diagnostics) {
+	if len(values) == 0 && current.IsNull() {
+		return current, nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, nonNil(values))
+}
+
+// nonNil returns an empty slice in place of nil so it converts to an empty set instead of a null one
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
 }
@@ -0,0 +1,137 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestPermissionValidateConfigChecksValidityWindow(t *testing.T) {
+	tests := []struct {
+		start, expired string
+		wantError      bool
+	}{
+		{"2026-04-01T00:00:00+08:00", "2026-04-30T18:00:00+08:00", false},
+		// Same instant as the start, written in another time zone
+		{"2026-04-01T00:00:00+08:00", "2026-03-31T16:00:00Z", true},
+		{"2026-04-01T00:00:00Z", "2026-03-31T23:00:00Z", true},
+	}
+
+	for _, tt := range tests {
+		config := resourceConfig(t, &PermissionResource{}, map[string]tftypes.Value{
+			"date_start":   tftypes.NewValue(tftypes.String, tt.start),
+			"date_expired": tftypes.NewValue(tftypes.String, tt.expired),
+		})
+
+		resp := &resource.ValidateConfigResponse{}
+		(&PermissionResource{}).ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("start %s, expired %s: expected error %t, got %v", tt.start, tt.expired, tt.wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestPermissionValidateConfigChecksAccounts(t *testing.T) {
+	tests := []struct {
+		accounts  []string
+		wantError bool
+	}{
+		{[]string{"@ALL"}, false},
+		{[]string{"@SPEC", "root", "deploy"}, false},
+		{[]string{"@INPUT", "@USER", "@ANON"}, false},
+		{[]string{"root"}, true},
+		{[]string{"@SPEC"}, true},
+		{[]string{"@ALL", "@SPEC", "root"}, true},
+	}
+
+	for _, tt := range tests {
+		elements := make([]tftypes.Value, 0, len(tt.accounts))
+		for _, a := range tt.accounts {
+			elements = append(elements, tftypes.NewValue(tftypes.String, a))
+		}
+		config := resourceConfig(t, &PermissionResource{}, map[string]tftypes.Value{
+			"accounts": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements),
+		})
+
+		resp := &resource.ValidateConfigResponse{}
+		(&PermissionResource{}).ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("accounts %v: expected error %t, got %v", tt.accounts, tt.wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestPermissionActionsValue(t *testing.T) {
+	ctx := context.Background()
+	set := func(values ...string) types.Set {
+		v, _ := types.SetValueFrom(ctx, types.StringType, values)
+		return v
+	}
+
+	tests := []struct {
+		name        string
+		server      []interface{}
+		prior       types.Set
+		want        types.Set
+		wantWarning bool
+	}{
+		{"keeps composite when equal", []interface{}{"connect", "upload", "download", "copy", "paste", "delete", "share"}, set("all"), set("all"), false},
+		{"keeps legacy names", []interface{}{"connect", "delete"}, set("connect", "delete_file"), set("connect", "delete_file"), false},
+		{"decodes bitmask", []interface{}{float64(25)}, set("connect", "clipboard"), set("connect", "clipboard"), false},
+		{"reports drift as atomic", []interface{}{"connect", "upload"}, set("connect", "transfer"), set("connect", "upload"), false},
+		{"atomic on import", []interface{}{map[string]interface{}{"value": "paste"}, "connect"}, types.SetNull(types.StringType), set("connect", "paste"), false},
+		{"drops unknown", []interface{}{"connect", "teleport"}, set("connect"), set("connect"), true},
+	}
+
+	for _, tt := range tests {
+		got, diags := permissionActionsValue(ctx, &jumpserver.Permission{Actions: tt.server}, tt.prior)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected error %v", tt.name, diags)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+		if (diags.WarningsCount() > 0) != tt.wantWarning {
+			t.Errorf("%s: expected warning %t, got %v", tt.name, tt.wantWarning, diags)
+		}
+	}
+}
+
+func TestPermissionDatesPlanDefaults(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 30, 15, 500, time.FixedZone("CST", 8*60*60))
+	start, expired := types.StringValue("2026-04-01T00:00:00Z"), types.StringValue("2026-04-30T18:00:00Z")
+
+	tests := []struct {
+		name                   string
+		configured             permissionDates
+		config                 PermissionResourceModel
+		wantStart, wantExpired types.String
+	}{
+		{"still configured", permissionDates{true, true},
+			PermissionResourceModel{DateStart: start, DateExpired: expired},
+			start, expired},
+		{"never configured", permissionDates{false, false},
+			PermissionResourceModel{DateStart: types.StringNull(), DateExpired: types.StringNull()},
+			start, expired},
+		{"expiry removed", permissionDates{true, true},
+			PermissionResourceModel{DateStart: start, DateExpired: types.StringNull()},
+			start, types.StringValue("2096-04-01T01:30:15Z")},
+		{"both removed", permissionDates{true, true},
+			PermissionResourceModel{DateStart: types.StringNull(), DateExpired: types.StringNull()},
+			types.StringValue("2026-04-01T01:30:15Z"), types.StringValue("2096-04-01T01:30:15Z")},
+	}
+
+	for _, tt := range tests {
+		plan := PermissionResourceModel{DateStart: start, DateExpired: expired}
+		tt.configured.planDefaults(tt.config, &plan, now)
+		if !plan.DateStart.Equal(tt.wantStart) || !plan.DateExpired.Equal(tt.wantExpired) {
+			t.Errorf("%s: expected %s to %s, got %s to %s", tt.name, tt.wantStart, tt.wantExpired, plan.DateStart, plan.DateExpired)
+		}
+	}
+}
@@ -0,0 +1,489 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ resource.Resource                   = &PlatformResource{}
+	_ resource.ResourceWithConfigure      = &PlatformResource{}
+	_ resource.ResourceWithImportState    = &PlatformResource{}
+	_ resource.ResourceWithValidateConfig = &PlatformResource{}
+)
+
+func NewPlatformResource() resource.Resource {
+	return &PlatformResource{}
+}
+
+type PlatformResource struct {
+	client *jumpserver.Client
+}
+
+type PlatformResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	CloneFrom     types.String   `tfsdk:"clone_from"`
+	Type          types.String   `tfsdk:"type"`
+	Category      types.String   `tfsdk:"category"`
+	Charset       types.String   `tfsdk:"charset"`
+	DomainEnabled types.Bool     `tfsdk:"domain_enabled"`
+	SuEnabled     types.Bool     `tfsdk:"su_enabled"`
+	SuMethod      types.String   `tfsdk:"su_method"`
+	Protocols     types.Set      `tfsdk:"protocols"`
+	Automation    types.Object   `tfsdk:"automation"`
+	Comment       types.String   `tfsdk:"comment"`
+	OrgID         types.String   `tfsdk:"org_id"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *PlatformResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_platform"
+}
+
+// computedString is an optional string that keeps its prior value when not configured
+func computedString(description string, validators ...validator.String) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: description,
+		Validators:  validators,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// computedBool is an optional bool that keeps its prior value when not configured
+func computedBool(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func (r *PlatformResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a custom JumpServer platform, optionally cloned from an existing one such as Linux",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The unique identifier of the platform",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the platform",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"clone_from": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID or name of a platform (e.g., 'Linux') whose settings are copied when the platform is created. Attributes set in the configuration override the copied values. Changing it forces a new platform",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The platform type (e.g., 'linux', 'windows', 'mysql'). Required unless clone_from is set. Changing it forces a new platform",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"category": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The platform category: host, database, web, device, cloud or custom. Required unless clone_from is set. Changing it forces a new platform",
+				Validators: []validator.String{
+					stringvalidator.OneOf(jumpserver.AssetCategories...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"charset": computedString(
+				"The character set used by terminal sessions: utf-8 or gbk",
+				stringvalidator.OneOf("utf-8", "gbk"),
+			),
+			"domain_enabled": computedBool("Whether assets of the platform can be reached through gateway domains"),
+			"su_enabled":     computedBool("Whether users may switch accounts with su"),
+			"su_method":      computedString("The account switch method (e.g., 'sudo', 'su', 'enable', 'super')"),
+			"protocols": schema.SetNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Protocols offered by the platform and their default ports. Per-protocol settings are kept from the cloned or current platform",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The protocol name (e.g., 'ssh', 'rdp', 'sftp')",
+						},
+						"port": schema.Int64Attribute{
+							Required:    true,
+							Description: "The default port of the protocol",
+							Validators: []validator.Int64{
+								int64validator.Between(0, 65535),
+							},
+						},
+						"primary": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Whether this is the primary protocol of the platform",
+						},
+						"required": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Whether assets must keep this protocol",
+						},
+						"default": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Whether new assets get this protocol by default",
+						},
+						"public": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+							Description: "Whether the protocol is shown to users",
+						},
+					},
+				},
+			},
+			"automation": schema.SingleNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Automation toggles and methods. Unset values keep the cloned or current settings",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"ansible_enabled":         computedBool("Whether Ansible based automation is enabled"),
+					"ping_enabled":            computedBool("Whether connectivity checks are enabled"),
+					"ping_method":             computedString("The connectivity check method (e.g., 'posix_ping')"),
+					"gather_facts_enabled":    computedBool("Whether hardware and OS facts are gathered"),
+					"gather_facts_method":     computedString("The fact gathering method (e.g., 'gather_facts_posix')"),
+					"push_account_enabled":    computedBool("Whether accounts can be pushed to assets"),
+					"push_account_method":     computedString("The account push method (e.g., 'push_account_posix')"),
+					"change_secret_enabled":   computedBool("Whether account secrets can be changed"),
+					"change_secret_method":    computedString("The secret change method (e.g., 'change_secret_posix')"),
+					"verify_account_enabled":  computedBool("Whether accounts can be verified"),
+					"verify_account_method":   computedString("The account verification method (e.g., 'verify_account_posix')"),
+					"gather_accounts_enabled": computedBool("Whether existing accounts are discovered"),
+					"gather_accounts_method":  computedString("The account discovery method (e.g., 'gather_accounts_posix')"),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Additional comments about the platform",
+			},
+			"org_id": orgIDAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *PlatformResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PlatformResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config PlatformResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.CloneFrom.IsNull() {
+		return
+	}
+
+	if config.Type.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Missing platform type",
+			"type is required when the platform is not cloned from another platform with clone_from.",
+		)
+	}
+	if config.Category.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("category"),
+			"Missing platform category",
+			"category is required when the platform is not cloned from another platform with clone_from.",
+		)
+	}
+}
+
+func (r *PlatformResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PlatformResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	var base *jumpserver.Platform
+	if !plan.CloneFrom.IsNull() {
+		source, err := r.lookupPlatform(ctx, plan.CloneFrom.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("clone_from"),
+				"Error reading platform to clone",
+				fmt.Sprintf("Could not read platform %s: %s", plan.CloneFrom.ValueString(), err),
+			)
+			return
+		}
+		base = source
+	}
+
+	createReq, diags := expandPlatform(ctx, &plan, base)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	platform, err := r.client.CreatePlatform(ctx, createReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error creating platform",
+			fmt.Sprintf("Could not create platform: %s", err),
+			err,
+		)
+		return
+	}
+
+	diags = flattenPlatform(ctx, platform, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created platform", map[string]any{"id": plan.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *PlatformResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PlatformResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	platform, err := r.client.GetPlatform(ctx, state.ID.ValueString())
+	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "platform no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading platform",
+			fmt.Sprintf("Could not read platform: %s", err),
+		)
+		return
+	}
+
+	diags = flattenPlatform(ctx, platform, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *PlatformResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PlatformResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	current, err := r.client.GetPlatform(ctx, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading platform",
+			fmt.Sprintf("Could not read platform ID %s before updating it: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	fields, diags := expandPlatform(ctx, &plan, current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateReq := &jumpserver.UpdatePlatformRequest{
+		Name:          fields.Name,
+		Type:          fields.Type,
+		Category:      fields.Category,
+		Charset:       fields.Charset,
+		DomainEnabled: fields.DomainEnabled,
+		SuEnabled:     fields.SuEnabled,
+		SuMethod:      fields.SuMethod,
+		Protocols:     fields.Protocols,
+		Automation:    fields.Automation,
+		Comment:       fields.Comment,
+	}
+
+	platform, err := r.client.UpdatePlatform(ctx, plan.ID.ValueString(), updateReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error updating platform",
+			fmt.Sprintf("Could not update platform: %s", err),
+			err,
+		)
+		return
+	}
+
+	diags = flattenPlatform(ctx, platform, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *PlatformResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PlatformResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	err := r.client.DeletePlatform(ctx, state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting platform",
+			fmt.Sprintf("Could not delete platform: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted platform", map[string]any{"id": state.ID.ValueString()})
+}
+
+func (r *PlatformResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithOrgID(ctx, req, resp)
+}
+
+// lookupPlatform finds a platform by numeric ID or by name
+func (r *PlatformResource) lookupPlatform(ctx context.Context, ref string) (*jumpserver.Platform, error) {
+	if _, err := strconv.Atoi(ref); err == nil {
+		return r.client.GetPlatform(ctx, ref)
+	}
+	return r.client.GetPlatformByName(ctx, ref)
+}
@@ -0,0 +1,218 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"jumpserver/internal/jumpserver"
+)
+
+// PlatformProtocolModel describes a protocol offered by a platform.
+type PlatformProtocolModel struct {
+	Name     types.String `tfsdk:"name"`
+	Port     types.Int64  `tfsdk:"port"`
+	Primary  types.Bool   `tfsdk:"primary"`
+	Required types.Bool   `tfsdk:"required"`
+	Default  types.Bool   `tfsdk:"default"`
+	Public   types.Bool   `tfsdk:"public"`
+}
+
+var platformProtocolAttrTypes = map[string]attr.Type{
+	"name":     types.StringType,
+	"port":     types.Int64Type,
+	"primary":  types.BoolType,
+	"required": types.BoolType,
+	"default":  types.BoolType,
+	"public":   types.BoolType,
+}
+
+// PlatformAutomationModel describes the automation settings of a platform.
+type PlatformAutomationModel struct {
+	AnsibleEnabled        types.Bool   `tfsdk:"ansible_enabled"`
+	PingEnabled           types.Bool   `tfsdk:"ping_enabled"`
+	PingMethod            types.String `tfsdk:"ping_method"`
+	GatherFactsEnabled    types.Bool   `tfsdk:"gather_facts_enabled"`
+	GatherFactsMethod     types.String `tfsdk:"gather_facts_method"`
+	PushAccountEnabled    types.Bool   `tfsdk:"push_account_enabled"`
+	PushAccountMethod     types.String `tfsdk:"push_account_method"`
+	ChangeSecretEnabled   types.Bool   `tfsdk:"change_secret_enabled"`
+	ChangeSecretMethod    types.String `tfsdk:"change_secret_method"`
+	VerifyAccountEnabled  types.Bool   `tfsdk:"verify_account_enabled"`
+	VerifyAccountMethod   types.String `tfsdk:"verify_account_method"`
+	GatherAccountsEnabled types.Bool   `tfsdk:"gather_accounts_enabled"`
+	GatherAccountsMethod  types.String `tfsdk:"gather_accounts_method"`
+}
+
+var platformAutomationAttrTypes = map[string]attr.Type{
+	"ansible_enabled":         types.BoolType,
+	"ping_enabled":            types.BoolType,
+	"ping_method":             types.StringType,
+	"gather_facts_enabled":    types.BoolType,
+	"gather_facts_method":     types.StringType,
+	"push_account_enabled":    types.BoolType,
+	"push_account_method":     types.StringType,
+	"change_secret_enabled":   types.BoolType,
+	"change_secret_method":    types.StringType,
+	"verify_account_enabled":  types.BoolType,
+	"verify_account_method":   types.StringType,
+	"gather_accounts_enabled": types.BoolType,
+	"gather_accounts_method":  types.StringType,
+}
+
+// expandPlatform builds a platform request from the plan. Attributes that are not
+// known yet are taken from base, which is the platform being cloned on create and the
+// current platform on update, so the server never resets them to its own defaults.
+// Without a base they are left to the server.
+func expandPlatform(ctx context.Context, plan *PlatformResourceModel, base *jumpserver.Platform) (*jumpserver.CreatePlatformRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var domainEnabled, suEnabled *bool
+	if base != nil {
+		domainEnabled = &base.DomainEnabled
+		suEnabled = &base.SuEnabled
+	} else {
+		base = &jumpserver.Platform{}
+	}
+
+	req := &jumpserver.CreatePlatformRequest{
+		Name:          plan.Name.ValueString(),
+		Type:          knownStringOr(plan.Type, base.GetTypeValue()),
+		Category:      knownStringOr(plan.Category, base.GetCategoryValue()),
+		Charset:       knownStringOr(plan.Charset, base.GetCharsetValue()),
+		DomainEnabled: knownBoolPointerOr(plan.DomainEnabled, domainEnabled),
+		SuEnabled:     knownBoolPointerOr(plan.SuEnabled, suEnabled),
+		SuMethod:      knownStringOr(plan.SuMethod, base.GetSuMethodValue()),
+		Protocols:     base.Protocols,
+		Comment:       plan.Comment.ValueString(),
+	}
+
+	if !plan.Protocols.IsNull() && !plan.Protocols.IsUnknown() {
+		var models []PlatformProtocolModel
+		diags.Append(plan.Protocols.ElementsAs(ctx, &models, false)...)
+
+		// Protocol settings are not managed here; keep the ones the base platform has
+		settings := make(map[string]*jumpserver.ProtocolSetting, len(base.Protocols))
+		for _, p := range base.Protocols {
+			settings[p.Name] = p.Setting
+		}
+
+		req.Protocols = make([]jumpserver.PlatformProtocol, 0, len(models))
+		for _, m := range models {
+			req.Protocols = append(req.Protocols, jumpserver.PlatformProtocol{
+				Name:     m.Name.ValueString(),
+				Port:     int(m.Port.ValueInt64()),
+				Primary:  m.Primary.ValueBool(),
+				Required: m.Required.ValueBool(),
+				Default:  m.Default.ValueBool(),
+				Public:   m.Public.ValueBool(),
+				Setting:  settings[m.Name.ValueString()],
+			})
+		}
+	}
+
+	automation := jumpserver.PlatformAutomation{}
+	if base.Automation != nil {
+		automation = *base.Automation
+	}
+	if !plan.Automation.IsNull() && !plan.Automation.IsUnknown() {
+		var m PlatformAutomationModel
+		diags.Append(plan.Automation.As(ctx, &m, basetypes.ObjectAsOptions{})...)
+
+		automation.AnsibleEnabled = knownBoolPointerOr(m.AnsibleEnabled, automation.AnsibleEnabled)
+		automation.PingEnabled = knownBoolPointerOr(m.PingEnabled, automation.PingEnabled)
+		automation.PingMethod = knownStringOr(m.PingMethod, automation.PingMethod)
+		automation.GatherFactsEnabled = knownBoolPointerOr(m.GatherFactsEnabled, automation.GatherFactsEnabled)
+		automation.GatherFactsMethod = knownStringOr(m.GatherFactsMethod, automation.GatherFactsMethod)
+		automation.PushAccountEnabled = knownBoolPointerOr(m.PushAccountEnabled, automation.PushAccountEnabled)
+		automation.PushAccountMethod = knownStringOr(m.PushAccountMethod, automation.PushAccountMethod)
+		automation.ChangeSecretEnabled = knownBoolPointerOr(m.ChangeSecretEnabled, automation.ChangeSecretEnabled)
+		automation.ChangeSecretMethod = knownStringOr(m.ChangeSecretMethod, automation.ChangeSecretMethod)
+		automation.VerifyAccountEnabled = knownBoolPointerOr(m.VerifyAccountEnabled, automation.VerifyAccountEnabled)
+		automation.VerifyAccountMethod = knownStringOr(m.VerifyAccountMethod, automation.VerifyAccountMethod)
+		automation.GatherAccountsEnabled = knownBoolPointerOr(m.GatherAccountsEnabled, automation.GatherAccountsEnabled)
+		automation.GatherAccountsMethod = knownStringOr(m.GatherAccountsMethod, automation.GatherAccountsMethod)
+	}
+	if automation != (jumpserver.PlatformAutomation{}) {
+		req.Automation = &automation
+	}
+
+	return req, diags
+}
+
+// flattenPlatform maps a platform returned by the API onto the resource model
+func flattenPlatform(ctx context.Context, platform *jumpserver.Platform, model *PlatformResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(platform.GetID())
+	model.Name = types.StringValue(platform.Name)
+	model.Type = types.StringValue(platform.GetTypeValue())
+	model.Category = types.StringValue(platform.GetCategoryValue())
+	model.Charset = types.StringValue(platform.GetCharsetValue())
+	model.DomainEnabled = types.BoolValue(platform.DomainEnabled)
+	model.SuEnabled = types.BoolValue(platform.SuEnabled)
+	model.SuMethod = types.StringValue(platform.GetSuMethodValue())
+	if platform.Comment != "" || !model.Comment.IsNull() {
+		model.Comment = types.StringValue(platform.Comment)
+	}
+
+	protocols := make([]PlatformProtocolModel, 0, len(platform.Protocols))
+	for _, p := range platform.Protocols {
+		protocols = append(protocols, PlatformProtocolModel{
+			Name:     types.StringValue(p.Name),
+			Port:     types.Int64Value(int64(p.Port)),
+			Primary:  types.BoolValue(p.Primary),
+			Required: types.BoolValue(p.Required),
+			Default:  types.BoolValue(p.Default),
+			Public:   types.BoolValue(p.Public),
+		})
+	}
+	set, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: platformProtocolAttrTypes}, protocols)
+	diags.Append(d...)
+	model.Protocols = set
+
+	if platform.Automation == nil {
+		model.Automation = types.ObjectNull(platformAutomationAttrTypes)
+		return diags
+	}
+
+	a := platform.Automation
+	obj, d := types.ObjectValueFrom(ctx, platformAutomationAttrTypes, PlatformAutomationModel{
+		AnsibleEnabled:        types.BoolPointerValue(a.AnsibleEnabled),
+		PingEnabled:           types.BoolPointerValue(a.PingEnabled),
+		PingMethod:            types.StringValue(a.PingMethod),
+		GatherFactsEnabled:    types.BoolPointerValue(a.GatherFactsEnabled),
+		GatherFactsMethod:     types.StringValue(a.GatherFactsMethod),
+		PushAccountEnabled:    types.BoolPointerValue(a.PushAccountEnabled),
+		PushAccountMethod:     types.StringValue(a.PushAccountMethod),
+		ChangeSecretEnabled:   types.BoolPointerValue(a.ChangeSecretEnabled),
+		ChangeSecretMethod:    types.StringValue(a.ChangeSecretMethod),
+		VerifyAccountEnabled:  types.BoolPointerValue(a.VerifyAccountEnabled),
+		VerifyAccountMethod:   types.StringValue(a.VerifyAccountMethod),
+		GatherAccountsEnabled: types.BoolPointerValue(a.GatherAccountsEnabled),
+		GatherAccountsMethod:  types.StringValue(a.GatherAccountsMethod),
+	})
+	diags.Append(d...)
+	model.Automation = obj
+
+	return diags
+}
+
+// knownStringOr returns the value when it is known and set, otherwise the fallback
+func knownStringOr(value types.String, fallback string) string {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return fallback
+	}
+	return value.ValueString()
+}
+
+// knownBoolPointerOr returns a pointer to the value when it is known, otherwise the fallback
+func knownBoolPointerOr(value types.Bool, fallback *bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return fallback
+	}
+	return value.ValueBoolPointer()
+}
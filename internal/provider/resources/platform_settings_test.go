@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestExpandPlatformOverridesClonedSettings(t *testing.T) {
+	ctx := context.Background()
+	enabled := true
+	linux := &jumpserver.Platform{
+		Name:      "Linux",
+		Type:      map[string]interface{}{"value": "linux", "label": "Linux"},
+		Category:  "host",
+		Charset:   "utf-8",
+		SuEnabled: true,
+		SuMethod:  "sudo",
+		Protocols: []jumpserver.PlatformProtocol{
+			{Name: "ssh", Port: 22, Primary: true, Setting: &jumpserver.ProtocolSetting{SFTPEnabled: &enabled}},
+		},
+		Automation: &jumpserver.PlatformAutomation{PingEnabled: &enabled, PingMethod: "posix_ping"},
+	}
+
+	protocols, diags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: platformProtocolAttrTypes}, []PlatformProtocolModel{{
+		Name:     types.StringValue("ssh"),
+		Port:     types.Int64Value(2222),
+		Primary:  types.BoolValue(true),
+		Required: types.BoolValue(false),
+		Default:  types.BoolValue(false),
+		Public:   types.BoolValue(true),
+	}})
+	if diags.HasError() {
+		t.Fatalf("failed to build protocols: %v", diags)
+	}
+
+	plan := &PlatformResourceModel{
+		Name:          types.StringValue("Hardened Linux"),
+		Type:          types.StringUnknown(),
+		Category:      types.StringUnknown(),
+		Charset:       types.StringUnknown(),
+		DomainEnabled: types.BoolUnknown(),
+		SuEnabled:     types.BoolValue(false),
+		SuMethod:      types.StringUnknown(),
+		Protocols:     protocols,
+		Automation:    types.ObjectUnknown(platformAutomationAttrTypes),
+	}
+
+	req, diags := expandPlatform(ctx, plan, linux)
+	if diags.HasError() {
+		t.Fatalf("expandPlatform returned errors: %v", diags)
+	}
+
+	if req.Name != "Hardened Linux" || req.Type != "linux" || req.Category != "host" || req.SuMethod != "sudo" {
+		t.Errorf("expected cloned type, category and su method, got %+v", req)
+	}
+	if req.SuEnabled == nil || *req.SuEnabled {
+		t.Error("expected su_enabled from the plan to override the cloned value")
+	}
+	if len(req.Protocols) != 1 || req.Protocols[0].Port != 2222 || req.Protocols[0].Setting == nil {
+		t.Errorf("expected ssh on port 2222 with the cloned settings, got %+v", req.Protocols)
+	}
+	if req.Automation == nil || req.Automation.PingMethod != "posix_ping" {
+		t.Errorf("expected the cloned automation settings, got %+v", req.Automation)
+	}
+}
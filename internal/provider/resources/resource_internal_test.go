@@ -0,0 +1,44 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceValue builds an object of the resource schema with every attribute null
+// except the given ones, and returns it together with the schema
+func resourceValue(t *testing.T, r resource.Resource, values map[string]tftypes.Value) (tftypes.Value, schema.Schema) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range values {
+		if _, ok := attrs[name]; !ok {
+			t.Fatalf("unknown attribute %q", name)
+		}
+		attrs[name] = value
+	}
+
+	return tftypes.NewValue(objectType, attrs), schemaResp.Schema
+}
+
+// resourceConfig builds a configuration of the resource with every attribute null
+// except the given ones
+func resourceConfig(t *testing.T, r resource.Resource, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	raw, s := resourceValue(t, r, values)
+	return tfsdk.Config{Schema: s, Raw: raw}
+}
@@ -0,0 +1,306 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ resource.Resource                   = &RoleBindingResource{}
+	_ resource.ResourceWithConfigure      = &RoleBindingResource{}
+	_ resource.ResourceWithImportState    = &RoleBindingResource{}
+	_ resource.ResourceWithValidateConfig = &RoleBindingResource{}
+)
+
+func NewRoleBindingResource() resource.Resource {
+	return &RoleBindingResource{}
+}
+
+type RoleBindingResource struct {
+	client *jumpserver.Client
+}
+
+type RoleBindingResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Scope    types.String   `tfsdk:"scope"`
+	Role     types.String   `tfsdk:"role"`
+	User     types.String   `tfsdk:"user"`
+	OrgID    types.String   `tfsdk:"org_id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *RoleBindingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_binding"
+}
+
+func (r *RoleBindingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Grants a JumpServer RBAC role to a user, either system-wide or within an organization",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The unique identifier of the role binding",
+			},
+			"scope": schema.StringAttribute{
+				Required:    true,
+				Description: "The scope of the role: 'system' or 'org'. Changing it forces a new resource",
+				Validators: []validator.String{
+					stringvalidator.OneOf(jumpserver.RoleScopeSystem, jumpserver.RoleScopeOrg),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the role to grant. Changing it forces a new resource",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the user receiving the role. Changing it forces a new resource",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the organization an org role is granted in. Defaults to the provider org_id and is read back from the binding. Changing it forces a new resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *RoleBindingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RoleBindingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config RoleBindingResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Scope.ValueString() == jumpserver.RoleScopeSystem && !config.OrgID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("org_id"),
+			"Unexpected org_id",
+			"System roles apply across every organization, so org_id can only be set when scope is 'org'.",
+		)
+	}
+}
+
+func (r *RoleBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RoleBindingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	createReq := &jumpserver.CreateRoleBindingRequest{
+		User: plan.User.ValueString(),
+		Role: plan.Role.ValueString(),
+		Org:  plan.OrgID.ValueString(),
+	}
+
+	binding, err := r.client.CreateRoleBinding(ctx, plan.Scope.ValueString(), createReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error creating role binding",
+			fmt.Sprintf("Could not grant role %s to user %s: %s", plan.Role.ValueString(), plan.User.ValueString(), err),
+			err,
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(binding.ID)
+	if orgID := binding.GetOrgID(); orgID != "" {
+		plan.OrgID = types.StringValue(orgID)
+	} else if plan.OrgID.IsUnknown() {
+		plan.OrgID = types.StringNull()
+	}
+
+	tflog.Trace(ctx, "created role binding", map[string]any{"id": plan.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *RoleBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RoleBindingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	binding, err := r.client.GetRoleBinding(ctx, state.Scope.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "role binding no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading role binding",
+			fmt.Sprintf("Could not read role binding: %s", err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(binding.ID)
+	state.User = types.StringValue(binding.GetUserID())
+	state.Role = types.StringValue(binding.GetRoleID())
+	if orgID := binding.GetOrgID(); orgID != "" {
+		state.OrgID = types.StringValue(orgID)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only applies timeout changes; every other attribute forces a new binding
+func (r *RoleBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RoleBindingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *RoleBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RoleBindingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	err := r.client.DeleteRoleBinding(ctx, state.Scope.ValueString(), state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting role binding",
+			fmt.Sprintf("Could not delete role binding: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted role binding", map[string]any{"id": state.ID.ValueString()})
+}
+
+// ImportState takes an ID in the form scope/id (e.g., 'system/3f1c...'). Org role
+// bindings of another organization are imported as org/<org_id>/<id>.
+func (r *RoleBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, id, ok := strings.Cut(req.ID, "/")
+	if !ok || (scope != jumpserver.RoleScopeSystem && scope != jumpserver.RoleScopeOrg) || id == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an ID in the form system/<id>, org/<id> or org/<org_id>/<id>, got %q.", req.ID),
+		)
+		return
+	}
+
+	if scope == jumpserver.RoleScopeOrg {
+		var orgID string
+		if orgID, id = splitImportID(id); orgID != "" {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgID)...)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
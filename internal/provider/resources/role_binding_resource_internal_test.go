@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestRoleBindingReadSetsOrgID(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/rbac/org-role-bindings/b1/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"b1","user":{"id":"u1"},"role":{"id":"r1"},"org":{"id":"o1","name":"Payments"}}`))
+	}))
+	defer server.Close()
+
+	raw, s := resourceValue(t, &RoleBindingResource{}, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "b1"),
+		"scope": tftypes.NewValue(tftypes.String, jumpserver.RoleScopeOrg),
+	})
+	r := &RoleBindingResource{client: jumpserver.NewClient(&jumpserver.Config{Endpoint: server.URL})}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: raw}}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state RoleBindingResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if state.OrgID.ValueString() != "o1" || state.User.ValueString() != "u1" || state.Role.ValueString() != "r1" {
+		t.Errorf("unexpected state %+v", state)
+	}
+}
+
+func TestRoleBindingImportState(t *testing.T) {
+	ctx := context.Background()
+	const org = "00000000-0000-0000-0000-000000000002"
+
+	tests := []struct {
+		id                string
+		wantScope, wantID string
+		wantOrgID         types.String
+		wantError         bool
+	}{
+		{"system/b1", jumpserver.RoleScopeSystem, "b1", types.StringNull(), false},
+		{"org/b1", jumpserver.RoleScopeOrg, "b1", types.StringNull(), false},
+		{"org/" + org + "/b1", jumpserver.RoleScopeOrg, "b1", types.StringValue(org), false},
+		{"b1", "", "", types.StringNull(), true},
+	}
+
+	for _, tt := range tests {
+		schemaResp := resource.SchemaResponse{}
+		(&RoleBindingResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		resp := &resource.ImportStateResponse{State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}}
+		(&RoleBindingResource{}).ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("%s: expected error %t, got %v", tt.id, tt.wantError, resp.Diagnostics)
+			continue
+		}
+		if tt.wantError {
+			continue
+		}
+
+		var scope, id, orgID types.String
+		resp.State.GetAttribute(ctx, path.Root("scope"), &scope)
+		resp.State.GetAttribute(ctx, path.Root("id"), &id)
+		resp.State.GetAttribute(ctx, path.Root("org_id"), &orgID)
+		if scope.ValueString() != tt.wantScope || id.ValueString() != tt.wantID || !orgID.Equal(tt.wantOrgID) {
+			t.Errorf("%s: expected %s/%s in %s, got %s/%s in %s", tt.id, tt.wantScope, tt.wantID, tt.wantOrgID, scope, id, orgID)
+		}
+	}
+}
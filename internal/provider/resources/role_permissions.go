@@ -0,0 +1,78 @@
+package resources
+
+import (
+	"sort"
+	"strconv"
+
+	"jumpserver/internal/jumpserver"
+)
+
+// permissionCatalog translates between permission codenames and the numeric IDs the role
+// API works with. Permissions are known by app_label.codename (e.g., 'assets.view_asset')
+// and, where it is unambiguous, by the bare codename.
+type permissionCatalog struct {
+	byName map[string]int
+	byID   map[int]jumpserver.RBACPermission
+}
+
+func newPermissionCatalog(permissions []jumpserver.RBACPermission) *permissionCatalog {
+	c := &permissionCatalog{
+		byName: make(map[string]int, len(permissions)*2),
+		byID:   make(map[int]jumpserver.RBACPermission, len(permissions)),
+	}
+
+	bare := make(map[string][]int)
+	for _, p := range permissions {
+		c.byID[p.ID] = p
+		c.byName[p.FullCodename()] = p.ID
+		bare[p.Codename] = append(bare[p.Codename], p.ID)
+	}
+	for codename, ids := range bare {
+		if _, ok := c.byName[codename]; !ok && len(ids) == 1 {
+			c.byName[codename] = ids[0]
+		}
+	}
+
+	return c
+}
+
+// resolve returns the IDs of the named permissions and the names that match none
+func (c *permissionCatalog) resolve(names []string) ([]int, []string) {
+	ids := make([]int, 0, len(names))
+	var unknown []string
+	for _, name := range names {
+		id, ok := c.byName[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, unknown
+}
+
+// names returns the codenames of the given permission IDs. Where a prior name refers to
+// the same permission it is kept, so bare codenames in the configuration do not drift
+// to their app_label.codename form.
+func (c *permissionCatalog) names(ids []int, prior []string) []string {
+	priorByID := make(map[int]string, len(prior))
+	for _, name := range prior {
+		if id, ok := c.byName[name]; ok {
+			priorByID[id] = name
+		}
+	}
+
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		switch p, ok := c.byID[id]; {
+		case priorByID[id] != "":
+			names = append(names, priorByID[id])
+		case ok:
+			names = append(names, p.FullCodename())
+		default:
+			names = append(names, strconv.Itoa(id))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,31 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	"jumpserver/internal/jumpserver"
+)
+
+func TestPermissionCatalog(t *testing.T) {
+	catalog := newPermissionCatalog([]jumpserver.RBACPermission{
+		{ID: 1, Codename: "view_asset", ContentType: map[string]interface{}{"app_label": "assets", "model": "asset"}},
+		{ID: 2, Codename: "view_account", ContentType: map[string]interface{}{"app_label": "accounts", "model": "account"}},
+		{ID: 3, Codename: "view_account", ContentType: map[string]interface{}{"app_label": "assets", "model": "account"}},
+		{ID: 4, Codename: "view_auditlog"},
+	})
+
+	ids, unknown := catalog.resolve([]string{"view_asset", "accounts.view_account", "view_account", "view_auditlog"})
+	if !reflect.DeepEqual(ids, []int{1, 2, 4}) {
+		t.Errorf("expected IDs [1 2 4], got %v", ids)
+	}
+	if !reflect.DeepEqual(unknown, []string{"view_account"}) {
+		t.Errorf("expected the ambiguous bare codename to be unknown, got %v", unknown)
+	}
+
+	names := catalog.names([]int{1, 3, 4, 99}, []string{"view_asset"})
+	want := []string{"99", "assets.view_account", "view_asset", "view_auditlog"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+}
@@ -0,0 +1,401 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"jumpserver/internal/jumpserver"
+)
+
+var (
+	_ resource.Resource                = &RoleResource{}
+	_ resource.ResourceWithConfigure   = &RoleResource{}
+	_ resource.ResourceWithImportState = &RoleResource{}
+	_ resource.ResourceWithModifyPlan  = &RoleResource{}
+)
+
+func NewRoleResource() resource.Resource {
+	return &RoleResource{}
+}
+
+type RoleResource struct {
+	client *jumpserver.Client
+}
+
+type RoleResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Scope       types.String   `tfsdk:"scope"`
+	DisplayName types.String   `tfsdk:"display_name"`
+	Permissions types.Set      `tfsdk:"permissions"`
+	Comment     types.String   `tfsdk:"comment"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a custom JumpServer RBAC role with a set of permissions. Roles, including org roles, are shared by every organization, so the resource has no org_id",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The unique identifier of the role",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the role",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"scope": schema.StringAttribute{
+				Required:    true,
+				Description: "Where the role applies: 'system' for roles granted across JumpServer, 'org' for roles granted within an organization. Changing it forces a new resource",
+				Validators: []validator.String{
+					stringvalidator.OneOf(jumpserver.RoleScopeSystem, jumpserver.RoleScopeOrg),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the role as shown in the JumpServer UI",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Permission codenames granted by the role, as app_label.codename (e.g., 'assets.view_asset') or as a bare codename when it is unambiguous. The jumpserver_permissions_catalog data source lists the available codenames; unknown codenames are rejected at plan time",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Additional comments about the role",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*jumpserver.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *jumpserver.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan marks display_name as changing on a rename and rejects permission
+// codenames the server does not know, so typos surface during plan instead of
+// failing halfway through an apply
+func (r *RoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan RoleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// JumpServer derives display_name from name, so a rename changes it too
+	if !req.State.Raw.IsNull() {
+		var name types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+		if !plan.Name.Equal(name) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("display_name"), types.StringUnknown())...)
+		}
+	}
+
+	if r.client == nil || plan.Scope.IsUnknown() || plan.Permissions.IsUnknown() {
+		return
+	}
+
+	_, diags := r.resolvePermissions(ctx, plan.Scope.ValueString(), plan.Permissions)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RoleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	scope := plan.Scope.ValueString()
+	catalog, diags := r.resolvePermissions(ctx, scope, plan.Permissions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	permissionIDs, _ := catalog.resolve(toStringSet(plan.Permissions))
+
+	createReq := &jumpserver.CreateRoleRequest{
+		Name:        plan.Name.ValueString(),
+		Permissions: permissionIDs,
+		Comment:     plan.Comment.ValueString(),
+	}
+
+	role, err := r.client.CreateRole(ctx, scope, createReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error creating role",
+			fmt.Sprintf("Could not create role: %s", err),
+			err,
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(role.ID)
+	resp.Diagnostics.Append(setRoleState(ctx, role, catalog, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created role", map[string]any{"id": plan.ID.ValueString(), "scope": scope})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RoleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	role, err := r.client.GetRole(ctx, state.Scope.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "role no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading role",
+			fmt.Sprintf("Could not read role: %s", err),
+		)
+		return
+	}
+
+	permissions, err := r.client.ListRBACPermissions(ctx, state.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role",
+			fmt.Sprintf("Could not list permissions: %s", err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(role.ID)
+	resp.Diagnostics.Append(setRoleState(ctx, role, newPermissionCatalog(permissions), &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RoleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	scope := plan.Scope.ValueString()
+	catalog, diags := r.resolvePermissions(ctx, scope, plan.Permissions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	permissionIDs, _ := catalog.resolve(toStringSet(plan.Permissions))
+
+	updateReq := &jumpserver.UpdateRoleRequest{
+		Name:        plan.Name.ValueString(),
+		Permissions: permissionIDs,
+		Comment:     plan.Comment.ValueString(),
+	}
+
+	role, err := r.client.UpdateRole(ctx, scope, plan.ID.ValueString(), updateReq)
+	if err != nil {
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
+			"Error updating role",
+			fmt.Sprintf("Could not update role: %s", err),
+			err,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(setRoleState(ctx, role, catalog, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RoleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteRole(ctx, state.Scope.ValueString(), state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting role",
+			fmt.Sprintf("Could not delete role: %s. Roles that are still bound to users cannot be deleted.", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted role", map[string]any{"id": state.ID.ValueString()})
+}
+
+// ImportState takes an ID in the form scope/id (e.g., 'org/7b8ad2d2-...')
+func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, id, ok := strings.Cut(req.ID, "/")
+	if !ok || (scope != jumpserver.RoleScopeSystem && scope != jumpserver.RoleScopeOrg) || id == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an ID in the form system/<id> or org/<id>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// resolvePermissions loads the permission catalog of the scope and reports every
+// configured codename it does not contain
+func (r *RoleResource) resolvePermissions(ctx context.Context, scope string, permissions types.Set) (*permissionCatalog, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	available, err := r.client.ListRBACPermissions(ctx, scope)
+	if err != nil {
+		diags.AddError(
+			"Error listing permissions",
+			fmt.Sprintf("Could not list the permissions available to %s roles: %s", scope, err),
+		)
+		return nil, diags
+	}
+
+	catalog := newPermissionCatalog(available)
+	if _, unknown := catalog.resolve(toStringSet(permissions)); len(unknown) > 0 {
+		diags.AddAttributeError(
+			path.Root("permissions"),
+			"Unknown permission",
+			fmt.Sprintf("The server has no %s permission named %s. Ambiguous bare codenames must be qualified with their app label (e.g., 'assets.view_asset'); see the jumpserver_permissions_catalog data source.", scope, strings.Join(unknown, ", ")),
+		)
+	}
+
+	return catalog, diags
+}
+
+// setRoleState maps a role returned by the API onto the resource model
+func setRoleState(ctx context.Context, role *jumpserver.Role, catalog *permissionCatalog, model *RoleResourceModel) diag.Diagnostics {
+	model.Name = types.StringValue(role.Name)
+	model.DisplayName = types.StringValue(role.DisplayName)
+	if scope := role.GetScopeValue(); scope != "" {
+		model.Scope = types.StringValue(scope)
+	}
+	if role.Comment != "" || !model.Comment.IsNull() {
+		model.Comment = types.StringValue(role.Comment)
+	}
+
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, catalog.names(role.Permissions, toStringSet(model.Permissions)))
+	model.Permissions = permissions
+	return diags
+}
@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRoleModifyPlanFollowsRename(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		planName string
+		want     types.String
+	}{
+		{"unchanged", "auditor", types.StringValue("Auditor")},
+		{"renamed", "reviewer", types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		stateRaw, roleSchema := resourceValue(t, &RoleResource{}, map[string]tftypes.Value{
+			"id":           tftypes.NewValue(tftypes.String, "1"),
+			"name":         tftypes.NewValue(tftypes.String, "auditor"),
+			"scope":        tftypes.NewValue(tftypes.String, "org"),
+			"display_name": tftypes.NewValue(tftypes.String, "Auditor"),
+		})
+		planRaw, _ := resourceValue(t, &RoleResource{}, map[string]tftypes.Value{
+			"id":           tftypes.NewValue(tftypes.String, "1"),
+			"name":         tftypes.NewValue(tftypes.String, tt.planName),
+			"scope":        tftypes.NewValue(tftypes.String, "org"),
+			"display_name": tftypes.NewValue(tftypes.String, "Auditor"),
+		})
+		req := resource.ModifyPlanRequest{
+			Plan:  tfsdk.Plan{Schema: roleSchema, Raw: planRaw},
+			State: tfsdk.State{Schema: roleSchema, Raw: stateRaw},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		(&RoleResource{}).ModifyPlan(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error %v", tt.name, resp.Diagnostics)
+		}
+
+		var got types.String
+		resp.Plan.GetAttribute(ctx, path.Root("display_name"), &got)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package resources
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	// errSSHKeyPassphrase reports a missing or wrong passphrase for an encrypted key
+	errSSHKeyPassphrase = errors.New("the private key is encrypted and the passphrase is missing or wrong")
+
+	// errSSHKeyNotEncrypted reports a passphrase given for a key that is not encrypted
+	errSSHKeyNotEncrypted = errors.New("the private key is not encrypted, so it takes no passphrase")
+)
+
+// sshKeyFingerprint parses a PEM or OpenSSH private key, decrypting it with the
+// passphrase when one is given, and returns the SHA256 fingerprint of its public key
+func sshKeyFingerprint(key, passphrase string) (string, error) {
+	var signer ssh.Signer
+	var err error
+	if passphrase == "" {
+		signer, err = ssh.ParsePrivateKey([]byte(key))
+	} else {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(key), []byte(passphrase))
+	}
+
+	var missing *ssh.PassphraseMissingError
+	switch {
+	case errors.As(err, &missing), errors.Is(err, x509.IncorrectPasswordError):
+		return "", errSSHKeyPassphrase
+	case err != nil && passphrase != "" && isNotEncryptedError(err):
+		return "", errSSHKeyNotEncrypted
+	case err != nil:
+		return "", fmt.Errorf("not a valid PEM or OpenSSH private key: %w", err)
+	}
+
+	return ssh.FingerprintSHA256(signer.PublicKey()), nil
+}
+
+// isNotEncryptedError reports whether the SSH package refused a passphrase because the
+// PEM or OpenSSH key is not encrypted. It returns untyped errors for these cases.
+func isNotEncryptedError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "not an encrypted key") || strings.Contains(msg, "key is not password protected")
+}
+
+// isSSHKeyPassphraseError reports whether a key error is caused by the passphrase
+// rather than by the key itself
+func isSSHKeyPassphraseError(err error) bool {
+	return errors.Is(err, errSSHKeyPassphrase) || errors.Is(err, errSSHKeyNotEncrypted)
+}
+
+// validateSSHKeySecret checks the configured secret of an account or template of the given
+// secret type. Only 'ssh_key' secrets take a passphrase and must parse as a private key;
+// passphrase problems are reported on passphrase, the rest on secret.
+func validateSSHKeySecret(secretType, secret, passphrase types.String, owner string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if secretType.IsUnknown() {
+		return diags
+	}
+
+	if secretType.ValueString() != "ssh_key" {
+		if !passphrase.IsNull() {
+			diags.AddAttributeError(
+				path.Root("passphrase"),
+				"Unexpected passphrase",
+				"A passphrase can only be set when secret_type is 'ssh_key'.",
+			)
+		}
+		return diags
+	}
+
+	if secret.IsNull() || secret.IsUnknown() || passphrase.IsUnknown() {
+		return diags
+	}
+
+	if _, err := sshKeyFingerprint(secret.ValueString(), passphrase.ValueString()); err != nil {
+		attribute := path.Root("secret")
+		if isSSHKeyPassphraseError(err) {
+			attribute = path.Root("passphrase")
+		}
+		diags.AddAttributeError(
+			attribute,
+			"Invalid SSH private key",
+			fmt.Sprintf("The secret of an 'ssh_key' %s must be a PEM or OpenSSH private key: %s.", owner, err),
+		)
+	}
+
+	return diags
+}
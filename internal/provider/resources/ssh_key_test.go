@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHKeyFingerprint(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ssh.FingerprintSHA256(sshPub)
+
+	openssh, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte("s3cret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPub, err := ssh.NewPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		key            string
+		passphrase     string
+		want           string
+		wantErr        bool
+		wantPassphrase bool
+	}{
+		{"openssh", string(pem.EncodeToMemory(openssh)), "", want, false, false},
+		{"pem", string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), "", ssh.FingerprintSHA256(ecPub), false, false},
+		{"encrypted", string(pem.EncodeToMemory(encrypted)), "s3cret", want, false, false},
+		{"missing passphrase", string(pem.EncodeToMemory(encrypted)), "", "", true, true},
+		{"wrong passphrase", string(pem.EncodeToMemory(encrypted)), "guess", "", true, true},
+		{"passphrase on openssh", string(pem.EncodeToMemory(openssh)), "s3cret", "", true, true},
+		{"passphrase on pem", string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), "s3cret", "", true, true},
+		{"not a key", "hunter2", "", "", true, false},
+		{"passphrase on not a key", "hunter2", "s3cret", "", true, false},
+	}
+
+	for _, tt := range tests {
+		got, err := sshKeyFingerprint(tt.key, tt.passphrase)
+		if (err != nil) != tt.wantErr || isSSHKeyPassphraseError(err) != tt.wantPassphrase {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+package resources
+
+import "time"
+
+// defaultTimeout bounds each CRUD operation when no timeouts block is configured
+const defaultTimeout = 5 * time.Minute
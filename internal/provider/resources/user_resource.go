@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -18,9 +24,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &UserResource{}
-	_ resource.ResourceWithConfigure   = &UserResource{}
-	_ resource.ResourceWithImportState = &UserResource{}
+	_ resource.Resource                   = &UserResource{}
+	_ resource.ResourceWithConfigure      = &UserResource{}
+	_ resource.ResourceWithImportState    = &UserResource{}
+	_ resource.ResourceWithValidateConfig = &UserResource{}
 )
 
 func NewUserResource() resource.Resource {
@@ -32,12 +39,25 @@ type UserResource struct {
 }
 
 type UserResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Username types.String `tfsdk:"username"`
-	Name     types.String `tfsdk:"name"`
-	Email    types.String `tfsdk:"email"`
-	IsActive types.Bool   `tfsdk:"is_active"`
-	Comment  types.String `tfsdk:"comment"`
+	ID                 types.String   `tfsdk:"id"`
+	Username           types.String   `tfsdk:"username"`
+	Name               types.String   `tfsdk:"name"`
+	Email              types.String   `tfsdk:"email"`
+	IsActive           types.Bool     `tfsdk:"is_active"`
+	Comment            types.String   `tfsdk:"comment"`
+	Groups             types.Set      `tfsdk:"groups"`
+	SystemRoles        types.Set      `tfsdk:"system_roles"`
+	OrgRoles           types.Set      `tfsdk:"org_roles"`
+	MFALevel           types.Int64    `tfsdk:"mfa_level"`
+	Source             types.String   `tfsdk:"source"`
+	DateExpired        types.String   `tfsdk:"date_expired"`
+	Phone              types.String   `tfsdk:"phone"`
+	Wechat             types.String   `tfsdk:"wechat"`
+	NeedUpdatePassword types.Bool     `tfsdk:"need_update_password"`
+	PasswordStrategy   types.String   `tfsdk:"password_strategy"`
+	Password           types.String   `tfsdk:"password"`
+	OrgID              types.String   `tfsdk:"org_id"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -79,6 +99,103 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 				Description: "Additional comments about the user",
 			},
+			"groups": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "IDs of the user groups the user belongs to. When set, the list is authoritative. Do not combine with jumpserver_user_group_membership for the same groups",
+			},
+			"system_roles": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "IDs of the system roles granted to the user. Defaults to the built-in User role",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_roles": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "IDs of the roles granted to the user in the organization of the resource. Defaults to the built-in OrgUser role",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mfa_level": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Multi-factor authentication level: 0 disabled, 1 enabled, 2 forced",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 2),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"source": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Where the user authenticates (e.g., 'local', 'ldap', 'openid', 'saml2'). Defaults to 'local'",
+				Validators: []validator.String{
+					stringvalidator.OneOf("local", "ldap", "openid", "radius", "cas", "saml2", "oauth2", "wecom", "dingtalk", "feishu", "custom"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"date_expired": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "When the user expires and can no longer log in, as an RFC 3339 timestamp (e.g., '2026-12-31T23:59:59Z')",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rfc3339Pattern, "must be an RFC 3339 timestamp such as 2026-12-31T23:59:59Z"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"phone": schema.StringAttribute{
+				Optional:    true,
+				Description: "The phone number of the user, including the country code (e.g., '+8613800000000')",
+			},
+			"wechat": schema.StringAttribute{
+				Optional:    true,
+				Description: "The WeChat ID of the user",
+			},
+			"need_update_password": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the user must change the password at the next login",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"password_strategy": schema.StringAttribute{
+				Optional:    true,
+				Description: "How the initial password is set when the user is created: 'email' sends a link to set it, 'custom' uses password. Defaults to 'custom' when password is set and 'email' otherwise",
+				Validators: []validator.String{
+					stringvalidator.OneOf(jumpserver.PasswordStrategyEmail, jumpserver.PasswordStrategyCustom),
+				},
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The initial password of the user. Write-only: it is sent when the user is created and never stored in state",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"org_id": orgIDAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -100,6 +217,38 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+func (r *UserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config UserResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.PasswordStrategy.IsUnknown() || config.Password.IsUnknown() {
+		return
+	}
+
+	switch config.PasswordStrategy.ValueString() {
+	case jumpserver.PasswordStrategyEmail:
+		if !config.Password.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Unexpected password",
+				"A password cannot be set when password_strategy is 'email'; the user sets it through the emailed link.",
+			)
+		}
+	case jumpserver.PasswordStrategyCustom:
+		if config.Password.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing password",
+				"A password is required when password_strategy is 'custom'.",
+			)
+		}
+	}
+}
+
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan UserResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -108,29 +257,62 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
+	// The password is write-only, so it is only available in the configuration
+	var password types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createReq := &jumpserver.CreateUserRequest{
-		Username: plan.Username.ValueString(),
-		Name:     plan.Name.ValueString(),
-		Email:    plan.Email.ValueString(),
-		IsActive: plan.IsActive.ValueBool(),
-		Comment:  plan.Comment.ValueString(),
+		Username:           plan.Username.ValueString(),
+		Name:               plan.Name.ValueString(),
+		Email:              plan.Email.ValueString(),
+		IsActive:           plan.IsActive.ValueBool(),
+		Comment:            plan.Comment.ValueString(),
+		Groups:             toStringSet(plan.Groups),
+		SystemRoles:        toStringSet(plan.SystemRoles),
+		OrgRoles:           toStringSet(plan.OrgRoles),
+		MFALevel:           knownIntPointer(plan.MFALevel),
+		Source:             plan.Source.ValueString(),
+		DateExpired:        plan.DateExpired.ValueString(),
+		Phone:              plan.Phone.ValueString(),
+		Wechat:             plan.Wechat.ValueString(),
+		NeedUpdatePassword: knownBoolPointerOr(plan.NeedUpdatePassword, nil),
+		PasswordStrategy:   plan.PasswordStrategy.ValueString(),
+		Password:           password.ValueString(),
+	}
+	if createReq.Password != "" && createReq.PasswordStrategy == "" {
+		createReq.PasswordStrategy = jumpserver.PasswordStrategyCustom
 	}
 
-	user, err := r.client.CreateUser(createReq)
+	user, err := r.client.CreateUser(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
 			"Error creating user",
 			fmt.Sprintf("Could not create user: %s", err),
+			err,
 		)
 		return
 	}
 
 	plan.ID = types.StringValue(user.ID)
-	plan.Username = types.StringValue(user.Username)
-	plan.Name = types.StringValue(user.Name)
-	plan.Email = types.StringValue(user.Email)
-	plan.IsActive = types.BoolValue(user.IsActive)
-	plan.Comment = types.StringValue(user.Comment)
+	resp.Diagnostics.Append(setUserState(ctx, user, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Trace(ctx, "created user", map[string]any{"id": plan.ID.ValueString()})
 
@@ -146,8 +328,25 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	user, err := r.client.GetUser(state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	user, err := r.client.GetUser(ctx, state.ID.ValueString())
 	if err != nil {
+		if jumpserver.IsNotFound(err) {
+			tflog.Warn(ctx, "user no longer exists, removing from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error reading user",
 			fmt.Sprintf("Could not read user: %s", err),
@@ -156,11 +355,10 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	state.ID = types.StringValue(user.ID)
-	state.Username = types.StringValue(user.Username)
-	state.Name = types.StringValue(user.Name)
-	state.Email = types.StringValue(user.Email)
-	state.IsActive = types.BoolValue(user.IsActive)
-	state.Comment = types.StringValue(user.Comment)
+	resp.Diagnostics.Append(setUserState(ctx, user, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -174,27 +372,59 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, plan.OrgID.ValueString())
+
 	updateReq := &jumpserver.UpdateUserRequest{
-		Username: plan.Username.ValueString(),
-		Name:     plan.Name.ValueString(),
-		Email:    plan.Email.ValueString(),
-		IsActive: &[]bool{plan.IsActive.ValueBool()}[0],
-		Comment:  plan.Comment.ValueString(),
+		Username:           plan.Username.ValueString(),
+		Name:               plan.Name.ValueString(),
+		Email:              plan.Email.ValueString(),
+		IsActive:           &[]bool{plan.IsActive.ValueBool()}[0],
+		Comment:            plan.Comment.ValueString(),
+		SystemRoles:        knownStringSetPointer(plan.SystemRoles),
+		OrgRoles:           knownStringSetPointer(plan.OrgRoles),
+		MFALevel:           knownIntPointer(plan.MFALevel),
+		Source:             knownStringPointer(plan.Source),
+		DateExpired:        knownStringPointer(plan.DateExpired),
+		Phone:              plan.Phone.ValueStringPointer(),
+		Wechat:             plan.Wechat.ValueStringPointer(),
+		NeedUpdatePassword: knownBoolPointerOr(plan.NeedUpdatePassword, nil),
+	}
+	// Clearing phone or wechat in the configuration clears them on the server
+	if plan.Phone.IsNull() {
+		updateReq.Phone = &[]string{""}[0]
+	}
+	if plan.Wechat.IsNull() {
+		updateReq.Wechat = &[]string{""}[0]
+	}
+	if !plan.Groups.IsNull() {
+		groups := toStringSet(plan.Groups)
+		updateReq.Groups = &groups
 	}
 
-	user, err := r.client.UpdateUser(plan.ID.ValueString(), updateReq)
+	user, err := r.client.UpdateUser(ctx, plan.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			ctx, &resp.Diagnostics, req.Plan.Schema,
 			"Error updating user",
 			fmt.Sprintf("Could not update user: %s", err),
+			err,
 		)
 		return
 	}
 
-	plan.Name = types.StringValue(user.Name)
-	plan.Email = types.StringValue(user.Email)
-	plan.IsActive = types.BoolValue(user.IsActive)
-	plan.Comment = types.StringValue(user.Comment)
+	resp.Diagnostics.Append(setUserState(ctx, user, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -208,8 +438,20 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DeleteUser(state.ID.ValueString())
-	if err != nil {
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ctx = jumpserver.WithOrgID(ctx, state.OrgID.ValueString())
+
+	err := r.client.DeleteUser(ctx, state.ID.ValueString())
+	// Already deleted out-of-band counts as success
+	if err != nil && !jumpserver.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting user",
 			fmt.Sprintf("Could not delete user: %s", err),
@@ -221,5 +463,82 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithOrgID(ctx, req, resp)
+}
+
+// setUserState maps a user returned by the API onto the resource model. The password and
+// password strategy are not returned by the API and keep their configured values.
+func setUserState(ctx context.Context, user *jumpserver.User, model *UserResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.Username = types.StringValue(user.Username)
+	model.Name = types.StringValue(user.Name)
+	model.Email = types.StringValue(user.Email)
+	model.IsActive = types.BoolValue(user.IsActive)
+	model.Comment = types.StringValue(user.Comment)
+	model.MFALevel = types.Int64Value(int64(user.GetMFALevelValue()))
+	model.Source = types.StringValue(user.GetSourceValue())
+	model.NeedUpdatePassword = types.BoolValue(user.NeedUpdatePassword)
+	if phone := user.GetPhoneValue(); phone != "" || !model.Phone.IsNull() {
+		model.Phone = types.StringValue(phone)
+	}
+	if user.Wechat != "" || !model.Wechat.IsNull() {
+		model.Wechat = types.StringValue(user.Wechat)
+	}
+
+	dateExpired, err := timeValue(user.DateExpired, model.DateExpired)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("date_expired"),
+			"Invalid expiry date",
+			fmt.Sprintf("Could not parse the expiry date of user %s: %s", user.Username, err),
+		)
+	}
+	model.DateExpired = dateExpired
+
+	var d diag.Diagnostics
+	model.Groups, d = userGroupsValue(ctx, user, model.Groups)
+	diags.Append(d...)
+	model.SystemRoles, d = types.SetValueFrom(ctx, types.StringType, user.GetSystemRoleIDs())
+	diags.Append(d...)
+	model.OrgRoles, d = types.SetValueFrom(ctx, types.StringType, user.GetOrgRoleIDs())
+	diags.Append(d...)
+
+	return diags
+}
+
+// userGroupsValue returns the groups of the user when they are managed. Unmanaged groups
+// stay null so memberships handled by jumpserver_user_group_membership never show up as drift.
+func userGroupsValue(ctx context.Context, user *jumpserver.User, current types.Set) (types.Set, diag.Diagnostics) {
+	if current.IsNull() {
+		return current, nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, user.GetGroupIDs())
+}
+
+// knownIntPointer returns a pointer to the value when it is known, otherwise nil
+func knownIntPointer(value types.Int64) *int {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	v := int(value.ValueInt64())
+	return &v
+}
+
+// knownStringPointer returns a pointer to the value when it is known, otherwise nil
+func knownStringPointer(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueStringPointer()
+}
+
+// knownStringSetPointer returns a pointer to the set elements when the set is known, so
+// an empty set is sent as an empty list instead of being left out, otherwise nil
+func knownStringSetPointer(value types.Set) *[]string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	values := nonNil(toStringSet(value))
+	return &values
 }